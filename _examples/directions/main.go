@@ -25,17 +25,17 @@ func main() {
 		Avoid:                   []am.DirectionsAvoid{am.DirectionsAvoidTolls},
 		Lang:                    language.AmericanEnglish,
 		RequestsAlternateRoutes: true,
-		SearchLocation: am.Location{
+		SearchLocation: &am.Location{
 			Latitude:  37.331871,
 			Longitude: -122.029626,
 		},
-		SearchRegion: am.Region{
+		SearchRegion: &am.Region{
 			EastLongitude: -122.029626,
 			NorthLatitude: 37.331871,
 			SouthLatitude: 37.331871,
 			WestLongitude: -122.029626,
 		},
-		UserLocation: am.Location{
+		UserLocation: &am.Location{
 			Latitude:  37.331871,
 			Longitude: -122.029626,
 		},
@@ -0,0 +1,278 @@
+package am
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AutocompleteSessionResult is the results for one input of an
+// AutocompleteSession.
+type AutocompleteSessionResult struct {
+	// The input the results are for.
+	Query string
+
+	// The sequence number of the input, increases with each Input call.
+	// Results are emitted in increasing Seq order, but not every Seq gets
+	// results: superseded inputs are skipped.
+	Seq uint64
+
+	Results []AutocompleteResult
+
+	// True if Results were filtered from the results of a previous input
+	// instead of fetched from the API.
+	Reused bool
+
+	Err error
+}
+
+// AutocompleteSessionOption configures an AutocompleteSession.
+type AutocompleteSessionOption func(*AutocompleteSession)
+
+// Will wait 200ms after the last input before sending a request by default.
+func WithSessionDebounce(d time.Duration) AutocompleteSessionOption {
+	return func(s *AutocompleteSession) {
+		s.debounce = d
+	}
+}
+
+// Enabled by default.
+//
+// When the input extends the input of the previous results, for example
+// "eiffel t" after "eiffel", the previous results matching the new input are
+// emitted without a request. A request is still sent if none matches.
+func WithSessionPrefixReuse(enabled bool) AutocompleteSessionOption {
+	return func(s *AutocompleteSession) {
+		s.prefixReuse = enabled
+	}
+}
+
+// AutocompleteSession drives SearchAutoComplete for a type-ahead input box.
+//
+// Feed every change of the input with Input, and read Results. The session
+// debounces the inputs, cancels the in-flight request once a newer input
+// arrives and drops responses for superseded inputs, so Results only carry
+// the results of the latest input.
+//
+//	session := am.NewAutocompleteSession(ctx, client, &am.SearchAutoCompleteRequest{Lang: language.French})
+//	defer session.Close()
+//	go func() {
+//		for result := range session.Results() {
+//			// render result.Results
+//		}
+//	}()
+//	session.Input("eif")
+//	session.Input("eiffel")
+type AutocompleteSession struct {
+	client      Client
+	base        SearchAutoCompleteRequest
+	debounce    time.Duration
+	prefixReuse bool
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	inputs  chan string
+	results chan AutocompleteSessionResult
+	done    chan struct{}
+	close   sync.Once
+}
+
+// NewAutocompleteSession starts a session, base is the template for every
+// request, its Query is ignored. The session stops when ctx is done or Close
+// is called.
+func NewAutocompleteSession(
+	ctx context.Context,
+	client Client,
+	base *SearchAutoCompleteRequest,
+	opts ...AutocompleteSessionOption,
+) *AutocompleteSession {
+	s := &AutocompleteSession{
+		client:      client,
+		debounce:    200 * time.Millisecond,
+		prefixReuse: true,
+		inputs:      make(chan string),
+		results:     make(chan AutocompleteSessionResult),
+		done:        make(chan struct{}),
+	}
+	if base != nil {
+		s.base = *base
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	go s.run()
+	return s
+}
+
+// Input sets the current text of the input box. It's a no-op after the
+// session stopped.
+func (s *AutocompleteSession) Input(query string) {
+	select {
+	case s.inputs <- query:
+	case <-s.done:
+	}
+}
+
+// Results returns the channel of results, closed after the session stopped.
+func (s *AutocompleteSession) Results() <-chan AutocompleteSessionResult {
+	return s.results
+}
+
+// Close stops the session and cancels the in-flight request.
+func (s *AutocompleteSession) Close() {
+	s.close.Do(s.cancel)
+	<-s.done
+}
+
+type autocompleteSessionResponse struct {
+	query string
+	seq   uint64
+	resp  *SearchAutocompleteResponse
+	err   error
+}
+
+func (s *AutocompleteSession) run() {
+	defer close(s.results)
+	defer close(s.done)
+
+	var (
+		seq       uint64
+		query     string
+		timer     = time.NewTimer(0)
+		pending   bool
+		inflight  uint64 // seq of the in-flight request, 0 if none
+		cancel    context.CancelFunc
+		lastQuery string
+		last      []AutocompleteResult
+		responses = make(chan autocompleteSessionResponse)
+		wg        sync.WaitGroup
+	)
+	if !timer.Stop() {
+		<-timer.C
+	}
+	stopInflight := func() {
+		if cancel != nil {
+			cancel()
+			cancel = nil
+		}
+		inflight = 0
+	}
+	defer func() {
+		timer.Stop()
+		stopInflight()
+		// Drain the canceled requests so they don't leak.
+		go func() {
+			for range responses {
+			}
+		}()
+		wg.Wait()
+		close(responses)
+	}()
+	emit := func(result AutocompleteSessionResult) bool {
+		select {
+		case s.results <- result:
+			return true
+		case <-s.ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+
+		case query = <-s.inputs:
+			seq++
+			stopInflight()
+			if !timer.Stop() && pending {
+				<-timer.C
+			}
+			pending = false
+			if strings.TrimSpace(query) == "" {
+				lastQuery, last = "", nil
+				if !emit(AutocompleteSessionResult{Query: query, Seq: seq}) {
+					return
+				}
+				continue
+			}
+			timer.Reset(s.debounce)
+			pending = true
+
+		case <-timer.C:
+			pending = false
+			if s.prefixReuse {
+				if reused := reuseAutocompleteResults(lastQuery, last, query); len(reused) > 0 {
+					lastQuery, last = query, reused
+					if !emit(AutocompleteSessionResult{Query: query, Seq: seq, Results: reused, Reused: true}) {
+						return
+					}
+					continue
+				}
+			}
+			ctx, cancelRequest := context.WithCancel(s.ctx)
+			cancel, inflight = cancelRequest, seq
+			req := s.base
+			req.Query = query
+			wg.Add(1)
+			go func(ctx context.Context, query string, seq uint64) {
+				defer wg.Done()
+				resp, err := s.client.SearchAutoComplete(ctx, &req)
+				responses <- autocompleteSessionResponse{query: query, seq: seq, resp: resp, err: err}
+			}(ctx, query, seq)
+
+		case r := <-responses:
+			if r.seq != inflight {
+				// Superseded by a newer input.
+				continue
+			}
+			stopInflight()
+			result := AutocompleteSessionResult{Query: r.query, Seq: r.seq, Err: r.err}
+			if r.err == nil {
+				result.Results = r.resp.Results
+				lastQuery, last = r.query, r.resp.Results
+			}
+			if !emit(result) {
+				return
+			}
+		}
+	}
+}
+
+// reuseAutocompleteResults returns the results of prevQuery that match query,
+// nil if query doesn't extend prevQuery.
+func reuseAutocompleteResults(prevQuery string, prev []AutocompleteResult, query string) []AutocompleteResult {
+	prevQuery = strings.ToLower(strings.TrimSpace(prevQuery))
+	query = strings.ToLower(strings.TrimSpace(query))
+	if prevQuery == "" || len(query) <= len(prevQuery) || !strings.HasPrefix(query, prevQuery) {
+		return nil
+	}
+	var matched []AutocompleteResult
+	for _, result := range prev {
+		if autocompleteResultMatches(result, query) {
+			matched = append(matched, result)
+		}
+	}
+	return matched
+}
+
+// autocompleteResultMatches reports whether every word of query is a prefix
+// of a word in the display lines of result.
+func autocompleteResultMatches(result AutocompleteResult, query string) bool {
+	words := strings.Fields(strings.ToLower(strings.Join(result.DisplayLines, " ")))
+	for _, term := range strings.Fields(query) {
+		found := false
+		for _, word := range words {
+			if strings.HasPrefix(word, term) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,174 @@
+package am_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAutocompleteClient struct {
+	am.Client
+
+	mu      sync.Mutex
+	queries []string
+	fn      func(ctx context.Context, req *am.SearchAutoCompleteRequest) (*am.SearchAutocompleteResponse, error)
+}
+
+func (c *fakeAutocompleteClient) SearchAutoComplete(ctx context.Context, req *am.SearchAutoCompleteRequest) (*am.SearchAutocompleteResponse, error) {
+	c.mu.Lock()
+	c.queries = append(c.queries, req.Query)
+	c.mu.Unlock()
+	return c.fn(ctx, req)
+}
+
+func (c *fakeAutocompleteClient) Queries() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.queries...)
+}
+
+func autocompleteResults(lines ...string) *am.SearchAutocompleteResponse {
+	resp := &am.SearchAutocompleteResponse{}
+	for _, line := range lines {
+		resp.Results = append(resp.Results, am.AutocompleteResult{DisplayLines: []string{line, "Paris, France"}})
+	}
+	return resp
+}
+
+func nextSessionResult(t *testing.T, session *am.AutocompleteSession) am.AutocompleteSessionResult {
+	t.Helper()
+	select {
+	case result := <-session.Results():
+		return result
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for session result")
+	}
+	return am.AutocompleteSessionResult{}
+}
+
+func TestAutocompleteSession_Debounce(t *testing.T) {
+	client := &fakeAutocompleteClient{
+		fn: func(ctx context.Context, req *am.SearchAutoCompleteRequest) (*am.SearchAutocompleteResponse, error) {
+			return autocompleteResults("Eiffel Tower"), nil
+		},
+	}
+	session := am.NewAutocompleteSession(context.Background(), client, nil, am.WithSessionDebounce(50*time.Millisecond))
+	defer session.Close()
+
+	session.Input("e")
+	session.Input("ei")
+	session.Input("eif")
+
+	result := nextSessionResult(t, session)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, "eif", result.Query)
+	assert.Equal(t, uint64(3), result.Seq)
+	assert.False(t, result.Reused)
+	assert.Equal(t, []string{"eif"}, client.Queries())
+}
+
+func TestAutocompleteSession_CancelSuperseded(t *testing.T) {
+	canceled := make(chan struct{})
+	started := make(chan struct{})
+	client := &fakeAutocompleteClient{
+		fn: func(ctx context.Context, req *am.SearchAutoCompleteRequest) (*am.SearchAutocompleteResponse, error) {
+			if req.Query == "slow" {
+				close(started)
+				<-ctx.Done()
+				close(canceled)
+				return nil, ctx.Err()
+			}
+			return autocompleteResults("Louvre"), nil
+		},
+	}
+	session := am.NewAutocompleteSession(
+		context.Background(), client, nil,
+		am.WithSessionDebounce(time.Millisecond),
+		am.WithSessionPrefixReuse(false),
+	)
+	defer session.Close()
+
+	session.Input("slow")
+	<-started
+	session.Input("louvre")
+
+	select {
+	case <-canceled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("superseded request is not canceled")
+	}
+	result := nextSessionResult(t, session)
+	assert.Equal(t, "louvre", result.Query)
+	assert.Equal(t, uint64(2), result.Seq)
+	assert.NoError(t, result.Err)
+}
+
+func TestAutocompleteSession_PrefixReuse(t *testing.T) {
+	client := &fakeAutocompleteClient{
+		fn: func(ctx context.Context, req *am.SearchAutoCompleteRequest) (*am.SearchAutocompleteResponse, error) {
+			return autocompleteResults("Eiffel Tower", "Eiffel Tower Restaurant", "Eiffel Bridge"), nil
+		},
+	}
+	session := am.NewAutocompleteSession(context.Background(), client, nil, am.WithSessionDebounce(time.Millisecond))
+	defer session.Close()
+
+	session.Input("eiffel")
+	result := nextSessionResult(t, session)
+	assert.Len(t, result.Results, 3)
+
+	session.Input("Eiffel Tower R")
+	result = nextSessionResult(t, session)
+	assert.True(t, result.Reused)
+	assert.Len(t, result.Results, 1)
+	assert.Equal(t, "Eiffel Tower Restaurant", result.Results[0].DisplayLines[0])
+
+	// Nothing matches, ask the API again.
+	session.Input("Eiffel Tower Rx")
+	result = nextSessionResult(t, session)
+	assert.False(t, result.Reused)
+	assert.Equal(t, []string{"eiffel", "Eiffel Tower Rx"}, client.Queries())
+}
+
+func TestAutocompleteSession_ErrorAndEmptyInput(t *testing.T) {
+	errBoom := errors.New("boom")
+	client := &fakeAutocompleteClient{
+		fn: func(ctx context.Context, req *am.SearchAutoCompleteRequest) (*am.SearchAutocompleteResponse, error) {
+			return nil, errBoom
+		},
+	}
+	session := am.NewAutocompleteSession(context.Background(), client, nil, am.WithSessionDebounce(time.Millisecond))
+	defer session.Close()
+
+	session.Input("eiffel")
+	result := nextSessionResult(t, session)
+	assert.ErrorIs(t, result.Err, errBoom)
+
+	session.Input("  ")
+	result = nextSessionResult(t, session)
+	assert.NoError(t, result.Err)
+	assert.Empty(t, result.Results)
+	assert.Equal(t, []string{"eiffel"}, client.Queries())
+}
+
+func TestAutocompleteSession_Close(t *testing.T) {
+	client := &fakeAutocompleteClient{
+		fn: func(ctx context.Context, req *am.SearchAutoCompleteRequest) (*am.SearchAutocompleteResponse, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	session := am.NewAutocompleteSession(context.Background(), client, nil, am.WithSessionDebounce(time.Millisecond))
+	session.Input("eiffel")
+	time.Sleep(10 * time.Millisecond)
+	session.Close()
+	session.Close()
+	session.Input("ignored")
+
+	_, ok := <-session.Results()
+	assert.False(t, ok)
+}
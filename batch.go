@@ -0,0 +1,114 @@
+package am
+
+import (
+	"context"
+	"sync"
+)
+
+const defaultBatchConcurrency = 4
+
+// BatchOptions configures a batch of requests, the zero value is valid.
+type BatchOptions[T any] struct {
+	// The number of requests in flight, 4 by default. The rate limiter set
+	// with WithRateLimiter applies on top of it.
+	Concurrency int
+
+	// Called once for every item after it completes, skipped ones included,
+	// with the number of items completed so far. Calls are serialized but
+	// come in completion order, not input order.
+	//
+	// Save the results here to resume an interrupted batch with Skip.
+	OnProgress func(result BatchResult[T], done, total int)
+
+	// Items for which Skip returns true are not requested, their results
+	// are marked Skipped. Use it to resume a batch with the items completed
+	// by a previous run.
+	Skip func(index int) bool
+}
+
+// BatchResult is the result of one item of a batch.
+type BatchResult[T any] struct {
+	// The index of the item in the requests.
+	Index int
+
+	// Nil if Err is set or the item is skipped.
+	Response *T
+
+	Err error
+
+	Skipped bool
+}
+
+// runBatch calls fn for every request with bounded concurrency and returns
+// the results in input order. Errors are per item, a canceled ctx fails the
+// items not started yet.
+func runBatch[Req, Resp any](
+	ctx context.Context,
+	reqs []Req,
+	opts *BatchOptions[Resp],
+	fn func(context.Context, *Req) (*Resp, error),
+) []BatchResult[Resp] {
+	if opts == nil {
+		opts = &BatchOptions[Resp]{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	results := make([]BatchResult[Resp], len(reqs))
+	var (
+		mu   sync.Mutex
+		done int
+	)
+	complete := func(result BatchResult[Resp]) {
+		results[result.Index] = result
+		if opts.OnProgress == nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		opts.OnProgress(result, done, len(reqs))
+	}
+
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	for i := 0; i < concurrency && i < len(reqs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				result := BatchResult[Resp]{Index: index}
+				if err := ctx.Err(); err != nil {
+					result.Err = err
+				} else {
+					result.Response, result.Err = fn(ctx, &reqs[index])
+				}
+				complete(result)
+			}
+		}()
+	}
+	for index := range reqs {
+		if opts.Skip != nil && opts.Skip(index) {
+			complete(BatchResult[Resp]{Index: index, Skipped: true})
+			continue
+		}
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// BatchGeocode runs client.Geocode for every request, results are in input
+// order with an error per item.
+func BatchGeocode(ctx context.Context, client Client, reqs []GeocodeRequest, opts *BatchOptions[PlaceResults]) []BatchResult[PlaceResults] {
+	return runBatch(ctx, reqs, opts, client.Geocode)
+}
+
+// BatchReverseGeocode runs client.ReverseGeocode for every request, results
+// are in input order with an error per item.
+func BatchReverseGeocode(ctx context.Context, client Client, reqs []ReverseRequest, opts *BatchOptions[PlaceResults]) []BatchResult[PlaceResults] {
+	return runBatch(ctx, reqs, opts, client.ReverseGeocode)
+}
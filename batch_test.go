@@ -0,0 +1,138 @@
+package am_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingLimiter struct {
+	calls atomic.Int64
+	err   error
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.calls.Add(1)
+	return l.err
+}
+
+func newBatchTestClient(t *testing.T, inflight, maxInflight *atomic.Int64, opts ...am.Option) am.Client {
+	return newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			max := maxInflight.Load()
+			if n <= max || maxInflight.CompareAndSwap(max, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if r.URL.Query().Get("q") == "unauthorized" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write(expectErrorResponse1)
+			return
+		}
+		_, _ = w.Write(expectPlaceResultsResponse1)
+	}, opts...)
+}
+
+func TestBatchGeocode(t *testing.T) {
+	var inflight, maxInflight atomic.Int64
+	limiter := &countingLimiter{}
+	client := newBatchTestClient(t, &inflight, &maxInflight, am.WithRateLimiter(limiter))
+
+	reqs := make([]am.GeocodeRequest, 20)
+	for i := range reqs {
+		reqs[i].Query = "1 Apple Park, Cupertino, CA"
+	}
+	reqs[3].Query = ""             // invalid, fails before sending
+	reqs[7].Query = "unauthorized" // fails at the API
+
+	var (
+		mu       sync.Mutex
+		progress []int
+	)
+	results := am.BatchGeocode(context.Background(), client, reqs, &am.BatchOptions[am.PlaceResults]{
+		Concurrency: 3,
+		OnProgress: func(result am.BatchResult[am.PlaceResults], done, total int) {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, len(reqs), total)
+			progress = append(progress, done)
+		},
+	})
+
+	assert.Len(t, results, len(reqs))
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		switch i {
+		case 3:
+			assert.Error(t, result.Err)
+		case 7:
+			var apiErr *am.ErrorFromAPI
+			assert.True(t, errors.As(result.Err, &apiErr))
+			assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+		default:
+			assert.NoError(t, result.Err)
+			assert.NotEmpty(t, result.Response.Results)
+		}
+	}
+	assert.LessOrEqual(t, maxInflight.Load(), int64(3))
+	assert.Equal(t, int64(len(reqs)), limiter.calls.Load())
+	assert.Len(t, progress, len(reqs))
+	for i, done := range progress {
+		assert.Equal(t, i+1, done)
+	}
+}
+
+func TestBatchReverseGeocode_Resume(t *testing.T) {
+	var inflight, maxInflight atomic.Int64
+	client := newBatchTestClient(t, &inflight, &maxInflight)
+
+	reqs := make([]am.ReverseRequest, 6)
+	for i := range reqs {
+		reqs[i].Loc = am.NewLocation(37.33, -122.03)
+	}
+	completed := map[int]bool{0: true, 2: true, 5: true}
+	results := am.BatchReverseGeocode(context.Background(), client, reqs, &am.BatchOptions[am.PlaceResults]{
+		Skip: func(index int) bool { return completed[index] },
+	})
+	for i, result := range results {
+		assert.Equal(t, completed[i], result.Skipped, i)
+		assert.NoError(t, result.Err)
+		assert.Equal(t, completed[i], result.Response == nil, i)
+	}
+
+	// Nil options use the defaults.
+	results = am.BatchReverseGeocode(context.Background(), client, reqs[:2], nil)
+	assert.Len(t, results, 2)
+	assert.NoError(t, results[1].Err)
+}
+
+func TestBatchGeocode_Canceled(t *testing.T) {
+	var inflight, maxInflight atomic.Int64
+	limiter := &countingLimiter{err: errors.New("rate limited")}
+	client := newBatchTestClient(t, &inflight, &maxInflight, am.WithRateLimiter(limiter))
+
+	reqs := []am.GeocodeRequest{{Query: "a"}, {Query: "b"}}
+	results := am.BatchGeocode(context.Background(), client, reqs, nil)
+	for _, result := range results {
+		assert.EqualError(t, result.Err, "rate limited")
+	}
+	assert.Zero(t, maxInflight.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results = am.BatchGeocode(ctx, newBatchTestClient(t, &inflight, &maxInflight), reqs, nil)
+	for _, result := range results {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+	}
+	assert.Empty(t, am.BatchGeocode(ctx, am.NewClient(""), nil, nil))
+}
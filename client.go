@@ -8,6 +8,8 @@ import (
 	"net/url"
 	"sync"
 	"time"
+
+	"golang.org/x/text/language"
 )
 
 const (
@@ -18,6 +20,8 @@ const (
 	V1_SEARCH_AUTOCOMPLETE = "https://maps-api.apple.com/v1/searchAutocomplete" // https://developer.apple.com/documentation/applemapsserverapi/search_for_places_that_meet_specific_criteria_to_autocomplete_a_place_search
 	V1_DIRECTIONS          = "https://maps-api.apple.com/v1/directions"         // https://developer.apple.com/documentation/applemapsserverapi/search_for_directions_and_estimated_travel_time_between_locations
 	V1_ETAS                = "https://maps-api.apple.com/v1/etas"               // https://developer.apple.com/documentation/applemapsserverapi/determine_estimated_arrival_times_and_distances_to_one_or_more_destinations
+	V1_PLACE               = "https://maps-api.apple.com/v1/place"              // https://developer.apple.com/documentation/applemapsserverapi/get_place_details
+	V1_PLACE_ALTERNATE_IDS = "https://maps-api.apple.com/v1/place/alternateIds" // https://developer.apple.com/documentation/applemapsserverapi/get_place_alternate_ids
 )
 
 // AccessTokenSaver is an interface to save and get access token.
@@ -89,8 +93,20 @@ type Client interface {
 	ReverseGeocode(context.Context, *ReverseRequest) (*PlaceResults, error)
 	Search(context.Context, *SearchRequest) (*SearchResponse, error)
 	SearchAutoComplete(context.Context, *SearchAutoCompleteRequest) (*SearchAutocompleteResponse, error)
+	// ResolveCompletion runs the search of AutocompleteResult.CompletionURL,
+	// lang overrides the lang of the URL unless it's language.Und.
+	ResolveCompletion(ctx context.Context, result *AutocompleteResult, lang language.Tag) (*SearchResponse, error)
 	Directions(context.Context, *DirectionsRequest) (*DirectionsResponse, error)
 	Eta(context.Context, *EtaRequest) (*EtaResponse, error)
+	Place(context.Context, *PlaceRequest) (*Place, error)
+	Places(context.Context, *PlacesRequest) (*PlacesResponse, error)
+	AlternateIDs(context.Context, *AlternateIDsRequest) (*AlternateIDsResponse, error)
+}
+
+// RateLimiter limits the rate of API requests, Wait blocks until a request
+// is allowed or ctx is done. [golang.org/x/time/rate.Limiter] implements it.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
 }
 
 type baseClient struct {
@@ -98,6 +114,8 @@ type baseClient struct {
 	tokenSaver    AccessTokenSaver
 	client        *http.Client
 	autoRefreshFn AutoRefresh
+	datum         *datumConversion
+	rateLimiter   RateLimiter
 }
 
 type Option func(*baseClient)
@@ -131,6 +149,28 @@ func WithHTTPClient(client *http.Client) Option {
 	}
 }
 
+// Coordinates are sent and returned as Apple Maps uses them by default:
+// GCJ-02 in China, WGS-84 elsewhere.
+//
+// With this option, the coordinates of requests are converted from datum
+// before sending, and the coordinates of responses are converted to datum.
+// Only coordinates in China are affected, see Datum.
+func WithDatum(datum Datum) Option {
+	return func(c *baseClient) {
+		c.datum = newDatumConversion(datum)
+	}
+}
+
+// No limit by default.
+//
+// Every API request waits for the limiter, token requests excepted, so
+// concurrent callers and batches share the rate.
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(c *baseClient) {
+		c.rateLimiter = limiter
+	}
+}
+
 func NewClient(authToken string, opts ...Option) Client {
 	c := &baseClient{
 		authToken:     authToken,
@@ -233,7 +273,25 @@ func doWithReadAccessToken[expect any](
 	if err != nil {
 		return nil, err
 	}
-	return do[expect](ctx, c.client, api, accessToken, req)
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if c.datum == nil {
+		return do[expect](ctx, c.client, api, accessToken, req)
+	}
+	if r, ok := req.(datumQuery); ok {
+		req = r.convertDatum(c.datum.toAPI)
+	}
+	resp, err := do[expect](ctx, c.client, api, accessToken, req)
+	if err != nil {
+		return nil, err
+	}
+	if r, ok := any(resp).(datumResponse); ok {
+		r.convertDatum(c.datum.fromAPI)
+	}
+	return resp, nil
 }
 
 func (c *baseClient) Geocode(ctx context.Context, req *GeocodeRequest) (*PlaceResults, error) {
@@ -252,6 +310,14 @@ func (c *baseClient) SearchAutoComplete(ctx context.Context, req *SearchAutoComp
 	return doWithReadAccessToken[SearchAutocompleteResponse](ctx, c, c.autoRefreshFn, V1_SEARCH_AUTOCOMPLETE, req)
 }
 
+func (c *baseClient) ResolveCompletion(ctx context.Context, result *AutocompleteResult, lang language.Tag) (*SearchResponse, error) {
+	req, err := newCompletionRequest(result, lang)
+	if err != nil {
+		return nil, err
+	}
+	return doWithReadAccessToken[SearchResponse](ctx, c, c.autoRefreshFn, V1_SEARCH, req)
+}
+
 func (c *baseClient) Directions(ctx context.Context, req *DirectionsRequest) (*DirectionsResponse, error) {
 	return doWithReadAccessToken[DirectionsResponse](ctx, c, c.autoRefreshFn, V1_DIRECTIONS, req)
 }
@@ -259,3 +325,18 @@ func (c *baseClient) Directions(ctx context.Context, req *DirectionsRequest) (*D
 func (c *baseClient) Eta(ctx context.Context, req *EtaRequest) (*EtaResponse, error) {
 	return doWithReadAccessToken[EtaResponse](ctx, c, c.autoRefreshFn, V1_ETAS, req)
 }
+
+func (c *baseClient) Place(ctx context.Context, req *PlaceRequest) (*Place, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return doWithReadAccessToken[Place](ctx, c, c.autoRefreshFn, V1_PLACE+"/"+url.PathEscape(req.ID), req)
+}
+
+func (c *baseClient) Places(ctx context.Context, req *PlacesRequest) (*PlacesResponse, error) {
+	return doWithReadAccessToken[PlacesResponse](ctx, c, c.autoRefreshFn, V1_PLACE, req)
+}
+
+func (c *baseClient) AlternateIDs(ctx context.Context, req *AlternateIDsRequest) (*AlternateIDsResponse, error) {
+	return doWithReadAccessToken[AlternateIDsResponse](ctx, c, c.autoRefreshFn, V1_PLACE_ALTERNATE_IDS, req)
+}
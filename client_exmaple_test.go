@@ -41,7 +41,7 @@ func ExampleClient_ReverseGeocode() {
 	client := am.NewClient("your_auth_token")
 	ctx := context.Background()
 	req := &am.ReverseRequest{
-		Loc: am.Location{
+		Loc: &am.Location{
 			Latitude:  40.714224,
 			Longitude: -73.961452,
 		},
@@ -68,6 +68,22 @@ func ExampleClient_Search() {
 	fmt.Println(resp)
 }
 
+func ExampleSearchAll() {
+	client := am.NewClient("your_auth_token")
+	ctx := context.Background()
+	req := &am.SearchRequest{
+		Query: "Cafe",
+		Lang:  language.French,
+	}
+	it := am.SearchAll(ctx, client, req, 50)
+	for it.Next() {
+		fmt.Println(it.Place().Name)
+	}
+	if err := it.Err(); err != nil {
+		panic(err)
+	}
+}
+
 func ExampleClient_SearchAutoComplete() {
 	client := am.NewClient("your_auth_token")
 	ctx := context.Background()
@@ -82,6 +98,24 @@ func ExampleClient_SearchAutoComplete() {
 	fmt.Println(resp)
 }
 
+func ExampleClient_ResolveCompletion() {
+	client := am.NewClient("your_auth_token")
+	ctx := context.Background()
+	completions, err := client.SearchAutoComplete(ctx, &am.SearchAutoCompleteRequest{
+		Query: "Eiffel",
+	})
+	if err != nil {
+		panic(err)
+	}
+	for _, result := range completions.Results {
+		resp, err := client.ResolveCompletion(ctx, &result, language.French)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(resp)
+	}
+}
+
 func ExampleClient_Directions() {
 	client := am.NewClient("your_auth_token")
 	ctx := context.Background()
@@ -101,7 +135,7 @@ func ExampleClient_Eta() {
 	client := am.NewClient("your_auth_token")
 	ctx := context.Background()
 	req := &am.EtaRequest{
-		Origin: am.Location{
+		Origin: &am.Location{
 			Latitude:  40.714224,
 			Longitude: -73.961452,
 		},
@@ -111,7 +145,7 @@ func ExampleClient_Eta() {
 				Longitude: -118.353378,
 			},
 		},
-		TransportType: am.EtasTransportTypeAutomobile,
+		TransportType: am.TransportTypeAutomobile,
 		DepartureDate: time.Now().Add(1 * time.Hour),
 	}
 	resp, err := client.Eta(ctx, req)
@@ -120,3 +154,43 @@ func ExampleClient_Eta() {
 	}
 	fmt.Println(resp)
 }
+
+func ExampleClient_Place() {
+	client := am.NewClient("your_auth_token")
+	ctx := context.Background()
+	req := &am.PlaceRequest{
+		ID:   "I63802885C8189B2B",
+		Lang: language.AmericanEnglish,
+	}
+	resp, err := client.Place(ctx, req)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(resp)
+}
+
+func ExampleClient_Places() {
+	client := am.NewClient("your_auth_token")
+	ctx := context.Background()
+	req := &am.PlacesRequest{
+		IDs: []string{"I63802885C8189B2B", "ICD5F9ABE8FB2E1B5"},
+	}
+	resp, err := client.Places(ctx, req)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(resp)
+}
+
+func ExampleClient_AlternateIDs() {
+	client := am.NewClient("your_auth_token")
+	ctx := context.Background()
+	req := &am.AlternateIDsRequest{
+		IDs: []string{"I63802885C8189B2B"},
+	}
+	resp, err := client.AlternateIDs(ctx, req)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(resp)
+}
@@ -2,7 +2,11 @@ package am_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"sync"
+
+	am "github.com/ringsaturn/am"
 )
 
 type FooTokenSaver struct {
@@ -24,3 +28,26 @@ func (s *FooTokenSaver) SetAccessToken(ctx context.Context, token string, exp in
 	s.exp = exp
 	return nil
 }
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// newTestClient returns a client whose API requests are served by handler
+// in memory.
+func newTestClient(handler http.HandlerFunc, opts ...am.Option) am.Client {
+	httpClient := &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			handler(rec, r)
+			return rec.Result(), nil
+		}),
+	}
+	return am.NewClient(
+		"your_auth_token",
+		append([]am.Option{
+			am.WithHTTPClient(httpClient),
+			am.WithAutoTokenRefresh(nil),
+		}, opts...)...,
+	)
+}
@@ -1,15 +1,32 @@
 package am
 
 import (
+	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
 )
 
+// Location is a point in decimal degrees. Unlike the response objects, it
+// doesn't keep unknown JSON fields, see ExtraFields.
 type Location struct {
 	Latitude  float64 `query:"latitude" json:"latitude" vd:"$>=-90 && $<=90"`
 	Longitude float64 `query:"longitude" json:"longitude" vd:"$>=-180 && $<=180"`
 }
 
+// NewLocation returns a pointer to a Location, handy for filling the optional
+// location fields of requests:
+//
+//	req := &am.GeocodeRequest{
+//		Query:          "Apple Park",
+//		SearchLocation: am.NewLocation(37.3349, -122.009),
+//	}
+func NewLocation(latitude, longitude float64) *Location {
+	return &Location{Latitude: latitude, Longitude: longitude}
+}
+
+// Deprecated: 0,0 is a valid coordinate. Request structs use *Location and
+// treat nil as unset, so check for nil instead.
 func (Location Location) IsEmpty() bool {
 	return Location.Latitude == 0 && Location.Longitude == 0
 }
@@ -22,6 +39,8 @@ func (location Location) QueryString() string {
 	}, ",")
 }
 
+// Region is a rectangle in decimal degrees. Unlike the response objects, it
+// doesn't keep unknown JSON fields, see ExtraFields.
 type Region struct {
 	EastLongitude float64 `json:"eastLongitude" query:"eastLongitude" vd:"$>=-180 && $<=180"`
 	NorthLatitude float64 `json:"northLatitude" query:"northLatitude" vd:"$>=-90 && $<=90"`
@@ -29,6 +48,8 @@ type Region struct {
 	WestLongitude float64 `json:"westLongitude" query:"westLongitude" vd:"$>=-180 && $<=180"`
 }
 
+// Deprecated: Request structs use *Region and treat nil as unset, so check
+// for nil instead.
 func (region Region) IsEmpty() bool {
 	return (region.EastLongitude == 0 && region.NorthLatitude == 0 &&
 		region.SouthLatitude == 0 && region.WestLongitude == 0)
@@ -45,58 +66,6 @@ func (region Region) QueryString() string {
 	}, ",")
 }
 
-// PoiCategory: 'A string that describes a specific point of interest (POI) category.'
-// https://developer.apple.com/documentation/applemapsserverapi/poicategory
-type PoiCategory string
-
-// Codes generated via ChatGPT
-const (
-	Airport         PoiCategory = "Airport"         // An airport.
-	AirportGate     PoiCategory = "AirportGate"     // A specific gate at an airport.
-	AirportTerminal PoiCategory = "AirportTerminal" // A specific named terminal at an airport.
-	AmusementPark   PoiCategory = "AmusementPark"   // An amusement park.
-	ATM             PoiCategory = "ATM"             // An automated teller machine.
-	Aquarium        PoiCategory = "Aquarium"        // An aquarium.
-	Bakery          PoiCategory = "Bakery"          // A bakery.
-	Bank            PoiCategory = "Bank"            // A bank.
-	Beach           PoiCategory = "Beach"           // A beach.
-	Brewery         PoiCategory = "Brewery"         // A brewery.
-	Cafe            PoiCategory = "Cafe"            // A cafe.
-	Campground      PoiCategory = "Campground"      // A campground.
-	CarRental       PoiCategory = "CarRental"       // A car rental location.
-	EVCharger       PoiCategory = "EVCharger"       // An electric vehicle (EV) charger.
-	FireStation     PoiCategory = "FireStation"     // A fire station.
-	FitnessCenter   PoiCategory = "FitnessCenter"   // A fitness center.
-	FoodMarket      PoiCategory = "FoodMarket"      // A food market.
-	GasStation      PoiCategory = "GasStation"      // A gas station.
-	Hospital        PoiCategory = "Hospital"        // A hospital.
-	Hotel           PoiCategory = "Hotel"           // A hotel.
-	Laundry         PoiCategory = "Laundry"         // A laundry.
-	Library         PoiCategory = "Library"         // A library.
-	Marina          PoiCategory = "Marina"          // A marina.
-	MovieTheater    PoiCategory = "MovieTheater"    // A movie theater.
-	Museum          PoiCategory = "Museum"          // A museum.
-	NationalPark    PoiCategory = "NationalPark"    // A national park.
-	Nightlife       PoiCategory = "Nightlife"       // A night life venue.
-	Park            PoiCategory = "Park"            // A park.
-	Parking         PoiCategory = "Parking"         // A parking location for an automobile.
-	Pharmacy        PoiCategory = "Pharmacy"        // A pharmacy.
-	Playground      PoiCategory = "Playground"      // A playground.
-	Police          PoiCategory = "Police"          // A police station.
-	PostOffice      PoiCategory = "PostOffice"      // A post office.
-	PublicTransport PoiCategory = "PublicTransport" // A public transportation station.
-	ReligiousSite   PoiCategory = "ReligiousSite"   // A religious site.
-	Restaurant      PoiCategory = "Restaurant"      // A restaurant.
-	Restroom        PoiCategory = "Restroom"        // A restroom.
-	School          PoiCategory = "School"          // A school.
-	Stadium         PoiCategory = "Stadium"         // A stadium.
-	Store           PoiCategory = "Store"           // A store.
-	Theater         PoiCategory = "Theater"         // A theater.
-	University      PoiCategory = "University"      // A university.
-	Winery          PoiCategory = "Winery"          // A winery.
-	Zoo             PoiCategory = "Zoo"             // A zoo.
-)
-
 type DirectionsAvoid string
 
 const (
@@ -108,10 +77,147 @@ const (
 	DirectionsAvoidTolls DirectionsAvoid = "Tolls"
 )
 
-// The mode of transportation the server returns directions for.
+// The mode of transportation, used by both directions and ETA.
+//
+// Responses may use other cases, for example `AUTOMOBILE`, they are
+// normalized to the constants below on unmarshal. Unknown values are kept
+// unchanged and aren't valid.
 type TransportType string
 
 const (
 	TransportTypeAutomobile TransportType = "Automobile"
 	TransportTypeWalking    TransportType = "Walking"
+	TransportTypeTransit    TransportType = "Transit" // ETA only.
+	TransportTypeCycling    TransportType = "Cycling"
+)
+
+var (
+	transportTypes = []TransportType{
+		TransportTypeAutomobile,
+		TransportTypeWalking,
+		TransportTypeTransit,
+		TransportTypeCycling,
+	}
+	directionsTransportTypes = map[TransportType]bool{
+		TransportTypeAutomobile: true,
+		TransportTypeWalking:    true,
+		TransportTypeCycling:    true,
+	}
+	etaTransportTypes = map[TransportType]bool{
+		TransportTypeAutomobile: true,
+		TransportTypeWalking:    true,
+		TransportTypeTransit:    true,
+		TransportTypeCycling:    true,
+	}
+)
+
+// UnknownTransportTypeError is returned when a transport type doesn't match
+// any of the known values, so a new mode of transportation is noticed instead
+// of compared as an arbitrary string.
+type UnknownTransportTypeError struct {
+	Value string
+}
+
+func (e *UnknownTransportTypeError) Error() string {
+	return fmt.Sprintf("am: unknown transport type %q", e.Value)
+}
+
+// ParseTransportType matches s against the known transport types
+// case-insensitively, so "AUTOMOBILE" returns TransportTypeAutomobile.
+func ParseTransportType(s string) (TransportType, error) {
+	for _, t := range transportTypes {
+		if strings.EqualFold(string(t), strings.TrimSpace(s)) {
+			return t, nil
+		}
+	}
+	return "", &UnknownTransportTypeError{Value: s}
+}
+
+// IsValid reports whether t is one of the known transport types.
+func (t TransportType) IsValid() bool {
+	for _, known := range transportTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalJSON normalizes the case of known values. Other values are kept
+// as is, so a new mode of transportation doesn't fail the whole response,
+// check IsValid to tell them apart.
+func (t *TransportType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if parsed, err := ParseTransportType(s); err == nil {
+		s = string(parsed)
+	}
+	*t = TransportType(s)
+	return nil
+}
+
+func validateTransportType(t TransportType, allowed map[TransportType]bool) error {
+	if t == "" || allowed[t] {
+		return nil
+	}
+	if !t.IsValid() {
+		return &UnknownTransportTypeError{Value: string(t)}
+	}
+	return fmt.Errorf("am: transport type %q is not supported by this API", t)
+}
+
+// ResultType describes the kind of result to include in search and
+// autocomplete responses, used by resultTypeFilter.
+type ResultType string
+
+const (
+	ResultTypeAddress         ResultType = "Address"         // An address.
+	ResultTypePoi             ResultType = "Poi"             // A point of interest.
+	ResultTypePhysicalFeature ResultType = "PhysicalFeature" // A physical feature, such as a mountain or a lake.
+	ResultTypeQuery           ResultType = "Query"           // A query suggestion, autocomplete only.
 )
+
+var (
+	searchResultTypes = map[ResultType]bool{
+		ResultTypeAddress:         true,
+		ResultTypePoi:             true,
+		ResultTypePhysicalFeature: true,
+	}
+	searchAutoCompleteResultTypes = map[ResultType]bool{
+		ResultTypeAddress:         true,
+		ResultTypePoi:             true,
+		ResultTypePhysicalFeature: true,
+		ResultTypeQuery:           true,
+	}
+)
+
+// AddressCategory describes a kind of address, used to include or exclude
+// address results by granularity.
+//
+// https://developer.apple.com/documentation/applemapsserverapi/addresscategory
+type AddressCategory string
+
+const (
+	AddressCategoryCountry               AddressCategory = "Country"               // A country.
+	AddressCategoryAdministrativeArea    AddressCategory = "AdministrativeArea"    // A state or province.
+	AddressCategorySubAdministrativeArea AddressCategory = "SubAdministrativeArea" // A county.
+	AddressCategoryLocality              AddressCategory = "Locality"              // A city.
+	AddressCategorySubLocality           AddressCategory = "SubLocality"           // A neighborhood or district within a city.
+	AddressCategoryPostalCode            AddressCategory = "PostalCode"            // A postal code.
+)
+
+// IsValid reports whether c is a known AddressCategory.
+func (c AddressCategory) IsValid() bool {
+	switch c {
+	case AddressCategoryCountry,
+		AddressCategoryAdministrativeArea,
+		AddressCategorySubAdministrativeArea,
+		AddressCategoryLocality,
+		AddressCategorySubLocality,
+		AddressCategoryPostalCode:
+		return true
+	}
+	return false
+}
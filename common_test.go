@@ -0,0 +1,58 @@
+package am_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTransportType(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    am.TransportType
+		wantErr bool
+	}{
+		{input: "Automobile", want: am.TransportTypeAutomobile},
+		{input: "AUTOMOBILE", want: am.TransportTypeAutomobile},
+		{input: "walking", want: am.TransportTypeWalking},
+		{input: "TRANSIT", want: am.TransportTypeTransit},
+		{input: "Cycling", want: am.TransportTypeCycling},
+		{input: "Teleport", wantErr: true},
+		{input: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := am.ParseTransportType(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTransportType(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseTransportType(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransportTypeUnmarshalJSON(t *testing.T) {
+	var eta am.EtaResponseEta
+	err := json.Unmarshal([]byte(`{"transportType":"AUTOMOBILE"}`), &eta)
+	assert.NoError(t, err)
+	assert.Equal(t, am.TransportTypeAutomobile, eta.TransportType)
+
+	err = json.Unmarshal([]byte(`{"transportType":""}`), &eta)
+	assert.NoError(t, err)
+	assert.Equal(t, am.TransportType(""), eta.TransportType)
+
+	// Unknown values don't fail the response.
+	err = json.Unmarshal([]byte(`{"transportType":"HOVERBOARD","distanceMeters":5}`), &eta)
+	assert.NoError(t, err)
+	assert.Equal(t, am.TransportType("HOVERBOARD"), eta.TransportType)
+	assert.False(t, eta.TransportType.IsValid())
+	assert.Equal(t, int64(5), eta.DistanceMeters)
+
+	err = json.Unmarshal([]byte(`{"transportType":1}`), &eta)
+	assert.Error(t, err)
+}
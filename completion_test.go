@@ -0,0 +1,74 @@
+package am_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func loadAutocompleteResults(t *testing.T) []am.AutocompleteResult {
+	resp := &am.SearchAutocompleteResponse{}
+	if err := json.Unmarshal(expectSearchAutocompleteResponse1, resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp.Results
+}
+
+func TestClient_ResolveCompletion(t *testing.T) {
+	results := loadAutocompleteResults(t)
+	tests := []struct {
+		name     string
+		result   am.AutocompleteResult
+		lang     language.Tag
+		wantLang string
+	}{
+		{name: "keep lang", result: results[0], lang: language.Und, wantLang: "fr-FR"},
+		{name: "override lang", result: results[0], lang: language.Japanese, wantLang: "ja"},
+		{name: "no lang", result: results[1], lang: language.Und, wantLang: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "maps-api.apple.com", r.URL.Host)
+				assert.Equal(t, "/v1/search", r.URL.Path)
+				assert.Equal(t, tt.wantLang, r.URL.Query().Get("lang"))
+				assert.NotEmpty(t, r.URL.Query().Get("q"))
+				assert.Contains(t, []string{"CgwIARIIRWlmZmVsIFQ=", "CgwIARIIRWlmZmVsIFI="}, r.URL.Query().Get("metadata"))
+				_, _ = w.Write(expectSearchResponse1)
+			})
+			resp, err := client.ResolveCompletion(context.Background(), &tt.result, tt.lang)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, "Eiffel Tower", resp.Results[0].Name)
+		})
+	}
+}
+
+func TestClient_ResolveCompletionInvalid(t *testing.T) {
+	client := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL)
+	})
+	tests := []struct {
+		name   string
+		result *am.AutocompleteResult
+	}{
+		{name: "nil", result: nil},
+		{name: "empty", result: &am.AutocompleteResult{}},
+		{name: "not search", result: &am.AutocompleteResult{CompletionURL: "/v1/geocode?q=Eiffel"}},
+		{name: "other host", result: &am.AutocompleteResult{CompletionURL: "https://example.com/v1/search?q=Eiffel"}},
+		{name: "no query", result: &am.AutocompleteResult{CompletionURL: "/v1/search?metadata=abc"}},
+		{name: "malformed", result: &am.AutocompleteResult{CompletionURL: "/v1/search?q=%zz"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.ResolveCompletion(context.Background(), tt.result, language.Und)
+			assert.Error(t, err)
+		})
+	}
+}
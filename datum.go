@@ -0,0 +1,430 @@
+package am
+
+import (
+	"fmt"
+	"math"
+)
+
+// Datum is a geodetic datum used for coordinates in mainland China.
+//
+// Chinese regulations require maps in China to offset coordinates with the
+// GCJ-02 obfuscation, which Apple Maps follows there. Baidu adds another
+// offset on top of GCJ-02, BD-09. GPS devices report WGS-84.
+//
+// Outside China every datum is WGS-84, conversions leave such coordinates
+// unchanged.
+type Datum int
+
+const (
+	WGS84 Datum = iota
+	GCJ02
+	BD09
+)
+
+func (d Datum) String() string {
+	switch d {
+	case WGS84:
+		return "WGS-84"
+	case GCJ02:
+		return "GCJ-02"
+	case BD09:
+		return "BD-09"
+	}
+	return fmt.Sprintf("Datum(%d)", int(d))
+}
+
+// chinaBoundary is a simplified outline of mainland China as longitude,
+// latitude pairs, within a few kilometres of the border. Hong Kong, Macau and
+// Taiwan are left out, Apple Maps uses WGS-84 there.
+var chinaBoundary = [...][2]float64{
+	// Amur, Ussuri and Tumen rivers, Russia and North Korea.
+	{123.2, 53.6}, {125.5, 53.1}, {126.3, 52.6}, {127.3, 51.5}, {127.6, 50.2},
+	{129.5, 49.4}, {130.7, 48.9}, {131.0, 47.7}, {132.5, 47.7}, {134.7, 48.3},
+	{134.2, 47.1}, {133.9, 46.3}, {133.1, 45.1}, {132.0, 45.3}, {131.0, 44.9},
+	{131.2, 44.0}, {131.3, 43.4}, {130.6, 42.4}, {130.25, 42.75}, {129.9, 42.95},
+	{129.6, 42.45}, {129.0, 42.15}, {128.1, 42.0}, {128.2, 41.45}, {127.2, 41.5},
+	{126.3, 41.1}, {125.3, 40.6}, {124.4, 40.05}, {124.1, 39.8},
+	// Yellow Sea, East China Sea and Taiwan Strait.
+	{123.3, 38.3}, {123.0, 36.8}, {122.3, 34.5}, {123.5, 31.0}, {123.0, 29.0},
+	{122.4, 28.0}, {120.6, 27.0}, {120.3, 26.3}, {120.0, 25.4}, {119.2, 24.6},
+	{118.5, 24.0}, {117.5, 23.0}, {115.5, 22.4},
+	// Around Hong Kong and Macau.
+	{114.62, 22.42}, {114.4, 22.55}, {114.22, 22.56}, {114.08, 22.53},
+	{113.95, 22.51}, {113.87, 22.45}, {113.75, 22.25}, {113.6, 22.23},
+	{113.57, 22.215}, {113.535, 22.215}, {113.545, 22.14}, {113.55, 22.05},
+	// South China Sea around Hainan, Gulf of Tonkin.
+	{112.5, 21.5}, {111.5, 21.2}, {111.3, 20.0}, {110.2, 18.0}, {108.8, 18.0},
+	{108.4, 19.2}, {108.5, 20.4}, {108.3, 21.45}, {108.0, 21.55},
+	// Vietnam, Laos and Myanmar.
+	{107.4, 21.65}, {106.7, 22.0}, {106.7, 22.85}, {106.0, 22.95}, {105.35, 23.38},
+	{104.85, 23.15}, {104.3, 22.75}, {103.95, 22.5}, {103.0, 22.5}, {102.15, 22.4},
+	{101.75, 21.15}, {101.15, 21.2}, {101.15, 21.57}, {100.2, 21.45}, {99.95, 22.05},
+	{99.2, 22.15}, {99.5, 22.9}, {98.9, 23.2}, {98.7, 23.95}, {97.7, 23.9},
+	{97.55, 24.75}, {97.75, 25.3}, {98.65, 25.9}, {98.7, 27.0}, {98.4, 27.7},
+	{98.2, 28.2}, {97.35, 28.2},
+	// India, Bhutan and Nepal, along the lines of actual control.
+	{96.4, 29.2}, {95.4, 29.1}, {94.5, 29.25}, {93.5, 28.7}, {92.5, 27.8},
+	{91.6, 27.95}, {90.5, 28.2}, {89.6, 28.2}, {89.0, 27.4}, {88.8, 28.0},
+	{88.6, 28.1}, {88.0, 27.9}, {86.9, 28.0}, {86.0, 28.1}, {85.1, 28.35},
+	{84.2, 28.6}, {83.0, 29.25}, {82.0, 30.0}, {81.1, 30.2}, {80.3, 30.4},
+	{79.5, 30.95}, {78.75, 31.3}, {79.4, 32.6}, {78.7, 33.7}, {78.2, 34.7},
+	{77.8, 35.5},
+	// Pakistan, Tajikistan, Kyrgyzstan and Kazakhstan.
+	{76.5, 35.9}, {75.4, 36.9}, {74.9, 37.25}, {75.2, 38.5}, {73.8, 38.95},
+	{73.6, 39.45}, {73.9, 39.7}, {74.9, 40.5}, {76.3, 40.4}, {77.7, 41.0},
+	{78.5, 41.5}, {80.2, 42.05}, {80.25, 42.85}, {80.8, 43.15}, {80.4, 44.1},
+	{80.5, 45.1}, {82.6, 45.4}, {82.3, 45.6}, {83.0, 47.2}, {85.6, 47.05},
+	{85.8, 48.4}, {86.6, 48.55}, {87.0, 49.0}, {87.8, 49.2},
+	// Mongolia.
+	{88.9, 48.0}, {90.1, 47.8}, {90.9, 46.3}, {91.0, 45.6}, {93.5, 44.95},
+	{95.4, 44.25}, {96.4, 42.75}, {100.8, 42.65}, {103.0, 41.9}, {105.0, 41.6},
+	{106.8, 42.3}, {109.3, 42.45}, {110.7, 43.2}, {112.0, 43.75}, {111.8, 45.0},
+	{113.6, 44.75}, {116.0, 45.6}, {117.4, 46.35}, {119.9, 46.7}, {119.7, 47.2},
+	{119.0, 47.5}, {118.2, 48.0}, {117.4, 47.65}, {116.9, 47.85}, {115.6, 47.9},
+	{116.0, 48.5}, {116.7, 49.85},
+	// Argun river, Russia.
+	{117.8, 49.5}, {119.2, 50.3}, {120.0, 51.6}, {120.8, 52.5}, {121.8, 53.3},
+}
+
+// chinaBounds is the bounding box of chinaBoundary, to skip the polygon test
+// for most points.
+var chinaBounds = func() Region {
+	bounds := Region{NorthLatitude: -90, SouthLatitude: 90, WestLongitude: 180, EastLongitude: -180}
+	for _, p := range chinaBoundary {
+		bounds.WestLongitude = math.Min(bounds.WestLongitude, p[0])
+		bounds.EastLongitude = math.Max(bounds.EastLongitude, p[0])
+		bounds.SouthLatitude = math.Min(bounds.SouthLatitude, p[1])
+		bounds.NorthLatitude = math.Max(bounds.NorthLatitude, p[1])
+	}
+	return bounds
+}()
+
+// InChina reports whether the point is in mainland China, where GCJ-02 and
+// BD-09 apply.
+//
+// The test uses a simplified outline of the border, points within a few
+// kilometres of it may be misclassified. Hong Kong, Macau and Taiwan are not
+// in mainland China.
+func (location Location) InChina() bool {
+	lon, lat := location.Longitude, location.Latitude
+	if lon < chinaBounds.WestLongitude || lon > chinaBounds.EastLongitude ||
+		lat < chinaBounds.SouthLatitude || lat > chinaBounds.NorthLatitude {
+		return false
+	}
+	// Count the edges crossed by a ray going east from the point.
+	inside := false
+	for i, j := 0, len(chinaBoundary)-1; i < len(chinaBoundary); j, i = i, i+1 {
+		a, b := chinaBoundary[i], chinaBoundary[j]
+		if (a[1] > lat) != (b[1] > lat) &&
+			lon < a[0]+(lat-a[1])*(b[0]-a[0])/(b[1]-a[1]) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Krasovsky 1940 ellipsoid, used by GCJ-02.
+const (
+	gcj02SemiMajorAxis   = 6378245.0
+	gcj02Eccentricity2   = 0.00669342162296594323
+	bd09OffsetMultiplier = math.Pi * 3000 / 180
+)
+
+func gcj02TransformLat(x, y float64) float64 {
+	ret := -100 + 2*x + 3*y + 0.2*y*y + 0.1*x*y + 0.2*math.Sqrt(math.Abs(x))
+	ret += (20*math.Sin(6*x*math.Pi) + 20*math.Sin(2*x*math.Pi)) * 2 / 3
+	ret += (20*math.Sin(y*math.Pi) + 40*math.Sin(y/3*math.Pi)) * 2 / 3
+	ret += (160*math.Sin(y/12*math.Pi) + 320*math.Sin(y*math.Pi/30)) * 2 / 3
+	return ret
+}
+
+func gcj02TransformLon(x, y float64) float64 {
+	ret := 300 + x + 2*y + 0.1*x*x + 0.1*x*y + 0.1*math.Sqrt(math.Abs(x))
+	ret += (20*math.Sin(6*x*math.Pi) + 20*math.Sin(2*x*math.Pi)) * 2 / 3
+	ret += (20*math.Sin(x*math.Pi) + 40*math.Sin(x/3*math.Pi)) * 2 / 3
+	ret += (150*math.Sin(x/12*math.Pi) + 300*math.Sin(x/30*math.Pi)) * 2 / 3
+	return ret
+}
+
+// gcj02Offset returns the GCJ-02 offset of a WGS-84 point, in degrees.
+func gcj02Offset(location Location) (dLat, dLon float64) {
+	x, y := location.Longitude-105, location.Latitude-35
+	dLat = gcj02TransformLat(x, y)
+	dLon = gcj02TransformLon(x, y)
+	radLat := toRadians(location.Latitude)
+	magic := math.Sin(radLat)
+	magic = 1 - gcj02Eccentricity2*magic*magic
+	sqrtMagic := math.Sqrt(magic)
+	dLat = dLat * 180 / ((gcj02SemiMajorAxis * (1 - gcj02Eccentricity2)) / (magic * sqrtMagic) * math.Pi)
+	dLon = dLon * 180 / (gcj02SemiMajorAxis / sqrtMagic * math.Cos(radLat) * math.Pi)
+	return dLat, dLon
+}
+
+func wgs84ToGCJ02(location Location) Location {
+	dLat, dLon := gcj02Offset(location)
+	return Location{Latitude: location.Latitude + dLat, Longitude: location.Longitude + dLon}
+}
+
+// invertOffset returns the point forward maps to target, refining guess by
+// fixed-point iteration. It converges fast since the offsets vary slowly,
+// the result is within 1e-10 degree.
+func invertOffset(forward func(Location) Location, target, guess Location) Location {
+	for i := 0; i < 30; i++ {
+		got := forward(guess)
+		dLat, dLon := target.Latitude-got.Latitude, target.Longitude-got.Longitude
+		guess.Latitude += dLat
+		guess.Longitude += dLon
+		if math.Abs(dLat) < 1e-10 && math.Abs(dLon) < 1e-10 {
+			break
+		}
+	}
+	return guess
+}
+
+func gcj02ToWGS84(location Location) Location {
+	return invertOffset(wgs84ToGCJ02, location, location)
+}
+
+func gcj02ToBD09(location Location) Location {
+	x, y := location.Longitude, location.Latitude
+	z := math.Hypot(x, y) + 0.00002*math.Sin(y*bd09OffsetMultiplier)
+	theta := math.Atan2(y, x) + 0.000003*math.Cos(x*bd09OffsetMultiplier)
+	return Location{Latitude: z*math.Sin(theta) + 0.006, Longitude: z*math.Cos(theta) + 0.0065}
+}
+
+func bd09ToGCJ02(location Location) Location {
+	return invertOffset(gcj02ToBD09, location, bd09ToGCJ02Approx(location))
+}
+
+// bd09ToGCJ02Approx is the usual closed form of the inverse of gcj02ToBD09,
+// off by up to 20 centimetres.
+func bd09ToGCJ02Approx(location Location) Location {
+	x, y := location.Longitude-0.0065, location.Latitude-0.006
+	z := math.Hypot(x, y) - 0.00002*math.Sin(y*bd09OffsetMultiplier)
+	theta := math.Atan2(y, x) - 0.000003*math.Cos(x*bd09OffsetMultiplier)
+	return Location{Latitude: z * math.Sin(theta), Longitude: z * math.Cos(theta)}
+}
+
+// ConvertDatum converts the point from one datum to another. Points outside
+// China are returned unchanged.
+//
+// WGS-84 to GCJ-02 and GCJ-02 to BD-09 are the reference formulas, the
+// reverse conversions invert them numerically to within a millimetre.
+func (location Location) ConvertDatum(from, to Datum) Location {
+	if from == to || !location.InChina() {
+		return location
+	}
+	// Go through GCJ-02, the datum in the middle.
+	switch from {
+	case WGS84:
+		location = wgs84ToGCJ02(location)
+	case BD09:
+		location = bd09ToGCJ02(location)
+	}
+	switch to {
+	case WGS84:
+		location = gcj02ToWGS84(location)
+	case BD09:
+		location = gcj02ToBD09(location)
+	}
+	return location
+}
+
+// WGS84ToGCJ02 is a shortcut of ConvertDatum(WGS84, GCJ02).
+func (location Location) WGS84ToGCJ02() Location { return location.ConvertDatum(WGS84, GCJ02) }
+
+// GCJ02ToWGS84 is a shortcut of ConvertDatum(GCJ02, WGS84).
+func (location Location) GCJ02ToWGS84() Location { return location.ConvertDatum(GCJ02, WGS84) }
+
+// GCJ02ToBD09 is a shortcut of ConvertDatum(GCJ02, BD09).
+func (location Location) GCJ02ToBD09() Location { return location.ConvertDatum(GCJ02, BD09) }
+
+// BD09ToGCJ02 is a shortcut of ConvertDatum(BD09, GCJ02).
+func (location Location) BD09ToGCJ02() Location { return location.ConvertDatum(BD09, GCJ02) }
+
+// WGS84ToBD09 is a shortcut of ConvertDatum(WGS84, BD09).
+func (location Location) WGS84ToBD09() Location { return location.ConvertDatum(WGS84, BD09) }
+
+// BD09ToWGS84 is a shortcut of ConvertDatum(BD09, WGS84).
+func (location Location) BD09ToWGS84() Location { return location.ConvertDatum(BD09, WGS84) }
+
+// The datum of Apple Maps coordinates in China.
+const apiDatum = GCJ02
+
+// locationConverter converts the coordinates of a request or a response.
+type locationConverter func(Location) Location
+
+func (convert locationConverter) location(location *Location) *Location {
+	if location == nil {
+		return nil
+	}
+	converted := convert(*location)
+	return &converted
+}
+
+// region converts the corners of the region, the zero Region stays zero.
+func (convert locationConverter) region(region Region) Region {
+	if region == (Region{}) {
+		return region
+	}
+	sw := convert(Location{Latitude: region.SouthLatitude, Longitude: region.WestLongitude})
+	ne := convert(Location{Latitude: region.NorthLatitude, Longitude: region.EastLongitude})
+	return Region{
+		EastLongitude: ne.Longitude,
+		NorthLatitude: ne.Latitude,
+		SouthLatitude: sw.Latitude,
+		WestLongitude: sw.Longitude,
+	}
+}
+
+func (convert locationConverter) regionPtr(region *Region) *Region {
+	if region == nil {
+		return nil
+	}
+	converted := convert.region(*region)
+	return &converted
+}
+
+// datumQuery is a request with coordinates, convertDatum returns a copy of
+// the request with the coordinates converted.
+type datumQuery interface {
+	query
+	convertDatum(convert locationConverter) query
+}
+
+var (
+	_ datumQuery = (*GeocodeRequest)(nil)
+	_ datumQuery = (*ReverseRequest)(nil)
+	_ datumQuery = (*SearchRequest)(nil)
+	_ datumQuery = (*SearchAutoCompleteRequest)(nil)
+	_ datumQuery = (*DirectionsRequest)(nil)
+	_ datumQuery = (*EtaRequest)(nil)
+)
+
+func (req *GeocodeRequest) convertDatum(convert locationConverter) query {
+	converted := *req
+	converted.SearchLocation = convert.location(req.SearchLocation)
+	converted.SearchRegion = convert.regionPtr(req.SearchRegion)
+	converted.UserLocation = convert.location(req.UserLocation)
+	return &converted
+}
+
+func (req *ReverseRequest) convertDatum(convert locationConverter) query {
+	converted := *req
+	converted.Loc = convert.location(req.Loc)
+	return &converted
+}
+
+func (req *SearchRequest) convertDatum(convert locationConverter) query {
+	converted := *req
+	converted.SearchLocation = convert.location(req.SearchLocation)
+	converted.SearchRegion = convert.regionPtr(req.SearchRegion)
+	converted.UserLocation = convert.location(req.UserLocation)
+	return &converted
+}
+
+func (req *SearchAutoCompleteRequest) convertDatum(convert locationConverter) query {
+	converted := *req
+	converted.SearchLocation = convert.location(req.SearchLocation)
+	converted.SearchRegion = convert.regionPtr(req.SearchRegion)
+	converted.UserLocation = convert.location(req.UserLocation)
+	return &converted
+}
+
+func (req *DirectionsRequest) convertDatum(convert locationConverter) query {
+	converted := *req
+	converted.Origin.Location = convert.location(req.Origin.Location)
+	converted.Destination.Location = convert.location(req.Destination.Location)
+	converted.SearchLocation = convert.location(req.SearchLocation)
+	converted.SearchRegion = convert.regionPtr(req.SearchRegion)
+	converted.UserLocation = convert.location(req.UserLocation)
+	return &converted
+}
+
+func (req *EtaRequest) convertDatum(convert locationConverter) query {
+	converted := *req
+	converted.Origin = convert.location(req.Origin)
+	if req.Destinations != nil {
+		converted.Destinations = make([]Location, len(req.Destinations))
+		for i, dest := range req.Destinations {
+			converted.Destinations[i] = convert(dest)
+		}
+	}
+	return &converted
+}
+
+// datumResponse is a response with coordinates, convertDatum converts them
+// in place.
+type datumResponse interface {
+	convertDatum(convert locationConverter)
+}
+
+var (
+	_ datumResponse = (*Place)(nil)
+	_ datumResponse = (*PlaceResults)(nil)
+	_ datumResponse = (*SearchResponse)(nil)
+	_ datumResponse = (*SearchAutocompleteResponse)(nil)
+	_ datumResponse = (*DirectionsResponse)(nil)
+	_ datumResponse = (*EtaResponse)(nil)
+	_ datumResponse = (*PlacesResponse)(nil)
+)
+
+func (place *Place) convertDatum(convert locationConverter) {
+	place.Coordinate = convert(place.Coordinate)
+	place.Center = convert.location(place.Center)
+	place.DisplayMapRegion = convert.region(place.DisplayMapRegion)
+}
+
+func convertPlacesDatum(places []Place, convert locationConverter) {
+	for i := range places {
+		places[i].convertDatum(convert)
+	}
+}
+
+func (resp *PlaceResults) convertDatum(convert locationConverter) {
+	convertPlacesDatum(resp.Results, convert)
+}
+
+func (resp *SearchResponse) convertDatum(convert locationConverter) {
+	resp.DisplayMapRegion = convert.region(resp.DisplayMapRegion)
+	convertPlacesDatum(resp.Results, convert)
+}
+
+func (resp *SearchAutocompleteResponse) convertDatum(convert locationConverter) {
+	for i := range resp.Results {
+		resp.Results[i].Location = convert(resp.Results[i].Location)
+	}
+}
+
+func (resp *DirectionsResponse) convertDatum(convert locationConverter) {
+	resp.Origin.convertDatum(convert)
+	resp.Destination.convertDatum(convert)
+	for _, path := range resp.StepPaths {
+		for i := range path {
+			path[i] = convert(path[i])
+		}
+	}
+}
+
+func (resp *EtaResponse) convertDatum(convert locationConverter) {
+	for i := range resp.Etas {
+		resp.Etas[i].Destination = convert(resp.Etas[i].Destination)
+	}
+}
+
+func (resp *PlacesResponse) convertDatum(convert locationConverter) {
+	convertPlacesDatum(resp.Results, convert)
+}
+
+// datumConversion converts between the datum of the caller and the one of
+// Apple Maps.
+type datumConversion struct {
+	toAPI, fromAPI locationConverter
+}
+
+func newDatumConversion(datum Datum) *datumConversion {
+	return &datumConversion{
+		toAPI:   func(location Location) Location { return location.ConvertDatum(datum, apiDatum) },
+		fromAPI: func(location Location) Location { return location.ConvertDatum(apiDatum, datum) },
+	}
+}
@@ -0,0 +1,149 @@
+package am_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocation_ConvertDatum(t *testing.T) {
+	tests := []struct {
+		name string
+		wgs  am.Location
+		gcj  am.Location
+	}{
+		{"Shanghai", am.Location{Latitude: 31.1774276, Longitude: 121.5272106}, am.Location{Latitude: 31.17530398364597, Longitude: 121.531541859215}},
+		{"Shenzhen", am.Location{Latitude: 22.543847, Longitude: 113.912316}, am.Location{Latitude: 22.540796131694766, Longitude: 113.9171764808363}},
+		{"Beijing", am.Location{Latitude: 39.911954, Longitude: 116.377817}, am.Location{Latitude: 39.91334545536069, Longitude: 116.38404722455657}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gcj := tt.wgs.WGS84ToGCJ02()
+			assert.InDelta(t, tt.gcj.Latitude, gcj.Latitude, 1e-9)
+			assert.InDelta(t, tt.gcj.Longitude, gcj.Longitude, 1e-9)
+
+			wgs := tt.gcj.GCJ02ToWGS84()
+			assert.InDelta(t, tt.wgs.Latitude, wgs.Latitude, 1e-8)
+			assert.InDelta(t, tt.wgs.Longitude, wgs.Longitude, 1e-8)
+
+			bd := tt.wgs.WGS84ToBD09()
+			assert.Greater(t, tt.wgs.Distance(bd), tt.wgs.Distance(gcj))
+			assert.Less(t, tt.gcj.Distance(bd.BD09ToGCJ02()), 1e-3)
+			assert.Less(t, tt.wgs.Distance(bd.BD09ToWGS84()), 1e-3)
+			assert.Equal(t, bd, gcj.GCJ02ToBD09())
+		})
+	}
+}
+
+func TestLocation_ConvertDatum_OutsideChina(t *testing.T) {
+	for _, loc := range []am.Location{
+		{Latitude: 48.8566, Longitude: 2.3522},
+		{Latitude: 37.78, Longitude: -122.42},
+		{Latitude: -33.86, Longitude: 151.21},
+	} {
+		assert.False(t, loc.InChina())
+		assert.Equal(t, loc, loc.WGS84ToGCJ02())
+		assert.Equal(t, loc, loc.WGS84ToBD09())
+		assert.Equal(t, loc, loc.BD09ToWGS84())
+	}
+	assert.Equal(t, "GCJ-02", am.GCJ02.String())
+}
+
+func TestLocation_InChina(t *testing.T) {
+	for name, loc := range map[string]am.Location{
+		"Beijing":   {Latitude: 39.9042, Longitude: 116.4074},
+		"Shanghai":  {Latitude: 31.2304, Longitude: 121.4737},
+		"Guangzhou": {Latitude: 23.1291, Longitude: 113.2644},
+		"Shenzhen":  {Latitude: 22.5431, Longitude: 114.0579},
+		"Zhuhai":    {Latitude: 22.2710, Longitude: 113.5767},
+		"Xiamen":    {Latitude: 24.4798, Longitude: 118.0894},
+		"Sanya":     {Latitude: 18.2528, Longitude: 109.5119},
+		"Harbin":    {Latitude: 45.8038, Longitude: 126.5350},
+		"Urumqi":    {Latitude: 43.8256, Longitude: 87.6168},
+		"Kashgar":   {Latitude: 39.4704, Longitude: 75.9898},
+		"Lhasa":     {Latitude: 29.6520, Longitude: 91.1721},
+		"Kunming":   {Latitude: 25.0389, Longitude: 102.7183},
+	} {
+		assert.True(t, loc.InChina(), name)
+	}
+
+	// Apple Maps uses WGS-84 in the neighbours of mainland China, their
+	// coordinates are left unchanged.
+	for name, loc := range map[string]am.Location{
+		"Seoul":       {Latitude: 37.5665, Longitude: 126.9780},
+		"Pyongyang":   {Latitude: 39.0392, Longitude: 125.7625},
+		"Osaka":       {Latitude: 34.6937, Longitude: 135.5023},
+		"Taipei":      {Latitude: 25.0330, Longitude: 121.5654},
+		"Kaohsiung":   {Latitude: 22.6273, Longitude: 120.3014},
+		"Hong Kong":   {Latitude: 22.3193, Longitude: 114.1694},
+		"Macau":       {Latitude: 22.1987, Longitude: 113.5439},
+		"Ulaanbaatar": {Latitude: 47.8864, Longitude: 106.9057},
+		"Hanoi":       {Latitude: 21.0278, Longitude: 105.8342},
+		"Manila":      {Latitude: 14.5995, Longitude: 120.9842},
+		"Vladivostok": {Latitude: 43.1198, Longitude: 131.8869},
+		"Almaty":      {Latitude: 43.2220, Longitude: 76.8512},
+		"Kathmandu":   {Latitude: 27.7172, Longitude: 85.3240},
+	} {
+		assert.False(t, loc.InChina(), name)
+		assert.Equal(t, loc, loc.WGS84ToGCJ02(), name)
+		assert.Equal(t, loc, loc.GCJ02ToWGS84(), name)
+	}
+
+	assert.Equal(t, "Datum(9)", am.Datum(9).String())
+}
+
+func parseQueryLocation(t *testing.T, s string) am.Location {
+	t.Helper()
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		t.Fatalf("invalid location %q", s)
+	}
+	lat, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lon, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return am.Location{Latitude: lat, Longitude: lon}
+}
+
+func TestWithDatum(t *testing.T) {
+	shanghai := am.Location{Latitude: 31.1774276, Longitude: 121.5272106}
+	paris := am.Location{Latitude: 48.8566, Longitude: 2.3522}
+
+	client := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		origin := parseQueryLocation(t, r.URL.Query().Get("origin"))
+		var etas []am.EtaResponseEta
+		for _, dest := range strings.Split(r.URL.Query().Get("destinations"), "|") {
+			etas = append(etas, am.EtaResponseEta{Destination: parseQueryLocation(t, dest)})
+		}
+		assert.InDelta(t, shanghai.WGS84ToGCJ02().Latitude, origin.Latitude, 1e-6)
+		assert.InDelta(t, shanghai.WGS84ToGCJ02().Longitude, origin.Longitude, 1e-6)
+		_ = json.NewEncoder(w).Encode(am.EtaResponse{Etas: etas})
+	}, am.WithDatum(am.WGS84))
+
+	req := &am.EtaRequest{
+		Origin:       am.NewLocation(shanghai.Latitude, shanghai.Longitude),
+		Destinations: []am.Location{shanghai, paris},
+	}
+	resp, err := client.Eta(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The request of the caller is untouched.
+	assert.Equal(t, shanghai, *req.Origin)
+	assert.Equal(t, shanghai, req.Destinations[0])
+
+	assert.Len(t, resp.Etas, 2)
+	assert.InDelta(t, shanghai.Latitude, resp.Etas[0].Destination.Latitude, 1e-6)
+	assert.InDelta(t, shanghai.Longitude, resp.Etas[0].Destination.Longitude, 1e-6)
+	assert.Equal(t, paris, resp.Etas[1].Destination)
+}
@@ -0,0 +1,190 @@
+package am
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidDirections is wrapped by the errors of DirectionsResponse
+// navigation when an index in the response points out of range.
+var ErrInvalidDirections = errors.New("am: invalid directions response")
+
+// DirectionsRoute is a route of a DirectionsResponse, with helpers to follow
+// its step indexes.
+type DirectionsRoute struct {
+	DirectionsResponseRoute
+
+	// The index of the route in DirectionsResponse.Routes.
+	Index int
+
+	resp *DirectionsResponse
+}
+
+// DirectionsStep is a step of a DirectionsResponse, with a helper to follow
+// its step path index.
+type DirectionsStep struct {
+	DirectionsResponseStep
+
+	// The index of the step in DirectionsResponse.Steps.
+	Index int
+
+	resp *DirectionsResponse
+}
+
+// MarshalJSON writes the route as DirectionsResponseRoute does, with an
+// "index" field. The embedded MarshalJSON alone would drop Index.
+func (r DirectionsRoute) MarshalJSON() ([]byte, error) {
+	route := r.DirectionsResponseRoute
+	route.Extra = withIndex(route.Extra, r.Index)
+	return route.MarshalJSON()
+}
+
+// MarshalJSON writes the step as DirectionsResponseStep does, with an
+// "index" field. The embedded MarshalJSON alone would drop Index.
+func (s DirectionsStep) MarshalJSON() ([]byte, error) {
+	step := s.DirectionsResponseStep
+	step.Extra = withIndex(step.Extra, s.Index)
+	return step.MarshalJSON()
+}
+
+// UnmarshalJSON reads what MarshalJSON writes, Index from the "index" field.
+// The route doesn't point to a response, its navigation helpers fail.
+func (r *DirectionsRoute) UnmarshalJSON(data []byte) error {
+	if err := r.DirectionsResponseRoute.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	index, err := takeIndex(&r.Extra)
+	r.Index = index
+	return err
+}
+
+// UnmarshalJSON reads what MarshalJSON writes, Index from the "index" field.
+// The step doesn't point to a response, Path fails.
+func (s *DirectionsStep) UnmarshalJSON(data []byte) error {
+	if err := s.DirectionsResponseStep.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	index, err := takeIndex(&s.Extra)
+	s.Index = index
+	return err
+}
+
+// withIndex returns a copy of extra with an "index" field.
+func withIndex(extra ExtraFields, index int) ExtraFields {
+	fields := make(ExtraFields, len(extra)+1)
+	for key, value := range extra {
+		fields[key] = value
+	}
+	fields["index"] = json.RawMessage(strconv.Itoa(index))
+	return fields
+}
+
+// takeIndex removes the "index" field from extra and returns its value, 0
+// without one. Keys match case-insensitively like encoding/json.
+func takeIndex(extra *ExtraFields) (int, error) {
+	index := 0
+	for key, value := range *extra {
+		if !strings.EqualFold(key, "index") {
+			continue
+		}
+		if err := json.Unmarshal(value, &index); err != nil {
+			return 0, fmt.Errorf("am: invalid index %s: %w", value, err)
+		}
+		delete(*extra, key)
+	}
+	if len(*extra) == 0 {
+		*extra = nil
+	}
+	return index, nil
+}
+
+// Validate checks every step index of the routes and every step path index
+// of the steps is in range, so the navigation helpers won't fail.
+func (resp *DirectionsResponse) Validate() error {
+	for i, step := range resp.Steps {
+		if step.StepPathIndex < 0 || step.StepPathIndex >= int64(len(resp.StepPaths)) {
+			return fmt.Errorf("%w: step %d has stepPathIndex %d, want [0, %d)",
+				ErrInvalidDirections, i, step.StepPathIndex, len(resp.StepPaths))
+		}
+	}
+	for i, route := range resp.Routes {
+		for _, index := range route.StepIndexes {
+			if index < 0 || index >= int64(len(resp.Steps)) {
+				return fmt.Errorf("%w: route %d has step index %d, want [0, %d)",
+					ErrInvalidDirections, i, index, len(resp.Steps))
+			}
+		}
+	}
+	return nil
+}
+
+// Route returns the i-th route, nil if i is out of range. The methods of a
+// nil route return an error wrapping ErrInvalidDirections, so
+// resp.Route(i).Steps() is safe.
+func (resp *DirectionsResponse) Route(i int) *DirectionsRoute {
+	if i < 0 || i >= len(resp.Routes) {
+		return nil
+	}
+	return &DirectionsRoute{DirectionsResponseRoute: resp.Routes[i], Index: i, resp: resp}
+}
+
+// Steps returns the steps of the route in order.
+func (r *DirectionsRoute) Steps() ([]DirectionsStep, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w: no such route", ErrInvalidDirections)
+	}
+	if r.resp == nil {
+		return nil, fmt.Errorf("%w: route %d isn't from DirectionsResponse.Route", ErrInvalidDirections, r.Index)
+	}
+	steps := make([]DirectionsStep, 0, len(r.StepIndexes))
+	for _, index := range r.StepIndexes {
+		if index < 0 || index >= int64(len(r.resp.Steps)) {
+			return nil, fmt.Errorf("%w: route %d has step index %d, want [0, %d)",
+				ErrInvalidDirections, r.Index, index, len(r.resp.Steps))
+		}
+		steps = append(steps, DirectionsStep{
+			DirectionsResponseStep: r.resp.Steps[index],
+			Index:                  int(index),
+			resp:                   r.resp,
+		})
+	}
+	return steps, nil
+}
+
+// Path returns the points of the step.
+func (s DirectionsStep) Path() ([]Location, error) {
+	if s.resp == nil {
+		return nil, fmt.Errorf("%w: step %d isn't from DirectionsRoute.Steps", ErrInvalidDirections, s.Index)
+	}
+	if s.StepPathIndex < 0 || s.StepPathIndex >= int64(len(s.resp.StepPaths)) {
+		return nil, fmt.Errorf("%w: step %d has stepPathIndex %d, want [0, %d)",
+			ErrInvalidDirections, s.Index, s.StepPathIndex, len(s.resp.StepPaths))
+	}
+	return s.resp.StepPaths[s.StepPathIndex], nil
+}
+
+// Polyline returns the points of the whole route.
+//
+// The last point of a step path is usually the first point of the next one,
+// such shared points are only kept once.
+func (r *DirectionsRoute) Polyline() ([]Location, error) {
+	steps, err := r.Steps()
+	if err != nil {
+		return nil, err
+	}
+	var polyline []Location
+	for _, step := range steps {
+		path, err := step.Path()
+		if err != nil {
+			return nil, err
+		}
+		if len(polyline) > 0 && len(path) > 0 && polyline[len(polyline)-1] == path[0] {
+			path = path[1:]
+		}
+		polyline = append(polyline, path...)
+	}
+	return polyline, nil
+}
@@ -0,0 +1,141 @@
+package am_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadDirectionsResponse(t testing.TB) *am.DirectionsResponse {
+	t.Helper()
+	resp := &am.DirectionsResponse{}
+	if err := json.Unmarshal(expectDirectionResponse1, resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestDirectionsResponse_Route(t *testing.T) {
+	resp := loadDirectionsResponse(t)
+	assert.NoError(t, resp.Validate())
+
+	route := resp.Route(0)
+	assert.Equal(t, "4th St", route.Name)
+	steps, err := route.Steps()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(resp.Routes[0].StepIndexes), len(steps))
+	assert.Equal(t, resp.Steps[0].Instructions, steps[0].Instructions)
+
+	path, err := steps[2].Path()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, resp.StepPaths[2], path)
+
+	assert.Nil(t, resp.Route(-1))
+	assert.Nil(t, resp.Route(len(resp.Routes)))
+}
+
+func TestDirectionsRoute_OutOfRange(t *testing.T) {
+	_, err := (&am.DirectionsResponse{}).Route(3).Steps()
+	assert.ErrorIs(t, err, am.ErrInvalidDirections)
+	_, err = (&am.DirectionsResponse{}).Route(3).Polyline()
+	assert.ErrorIs(t, err, am.ErrInvalidDirections)
+	assert.ErrorIs(t, (&am.DirectionsResponse{}).Route(3).WriteGPX(io.Discard), am.ErrInvalidDirections)
+
+	// Zero values don't point to a response.
+	_, err = (&am.DirectionsRoute{}).Steps()
+	assert.ErrorIs(t, err, am.ErrInvalidDirections)
+	_, err = am.DirectionsStep{}.Path()
+	assert.ErrorIs(t, err, am.ErrInvalidDirections)
+}
+
+func TestDirectionsRoute_MarshalJSON(t *testing.T) {
+	resp := loadDirectionsResponse(t)
+	route := resp.Route(0)
+	route.Index = 1
+	b, err := json.Marshal(route)
+	assert.NoError(t, err)
+	var fields map[string]any
+	assert.NoError(t, json.Unmarshal(b, &fields))
+	assert.Equal(t, float64(1), fields["index"])
+	assert.Equal(t, "4th St", fields["name"])
+	assert.Nil(t, route.Extra["index"])
+
+	steps, err := route.Steps()
+	assert.NoError(t, err)
+	b, err = json.Marshal(steps)
+	assert.NoError(t, err)
+	var stepFields []map[string]any
+	assert.NoError(t, json.Unmarshal(b, &stepFields))
+	for i, step := range steps {
+		assert.Equal(t, float64(step.Index), stepFields[i]["index"])
+		assert.Equal(t, step.Instructions, stepFields[i]["instructions"])
+	}
+
+	// The index round trips and stays out of Extra.
+	var decodedSteps []am.DirectionsStep
+	assert.NoError(t, json.Unmarshal(b, &decodedSteps))
+	for i, step := range steps {
+		assert.Equal(t, step.Index, decodedSteps[i].Index)
+		assert.Equal(t, step.Instructions, decodedSteps[i].Instructions)
+		assert.Nil(t, decodedSteps[i].Extra)
+	}
+	var decoded am.DirectionsRoute
+	assert.NoError(t, json.Unmarshal([]byte(`{"name":"a","index":2,"other":true}`), &decoded))
+	assert.Equal(t, 2, decoded.Index)
+	assert.Equal(t, "a", decoded.Name)
+	assert.Equal(t, am.ExtraFields{"other": json.RawMessage("true")}, decoded.Extra)
+	_, err = decoded.Steps()
+	assert.ErrorIs(t, err, am.ErrInvalidDirections)
+	assert.Error(t, json.Unmarshal([]byte(`{"index":"2"}`), &decoded))
+}
+
+func TestDirectionsRoute_Polyline(t *testing.T) {
+	resp := loadDirectionsResponse(t)
+	for i := range resp.Routes {
+		route := resp.Route(i)
+		polyline, err := route.Polyline()
+		if err != nil {
+			t.Fatal(err)
+		}
+		steps, _ := route.Steps()
+		total := 0
+		for _, step := range steps {
+			path, _ := step.Path()
+			total += len(path)
+		}
+		assert.Less(t, len(polyline), total)
+		for j := 1; j < len(polyline); j++ {
+			assert.NotEqual(t, polyline[j-1], polyline[j], "route %d point %d is duplicated", i, j)
+		}
+		first, _ := steps[0].Path()
+		last, _ := steps[len(steps)-1].Path()
+		assert.Equal(t, first[0], polyline[0])
+		assert.Equal(t, last[len(last)-1], polyline[len(polyline)-1])
+	}
+}
+
+func TestDirectionsResponse_Invalid(t *testing.T) {
+	resp := loadDirectionsResponse(t)
+	resp.Routes[1].StepIndexes = append(resp.Routes[1].StepIndexes, 100)
+	assert.True(t, errors.Is(resp.Validate(), am.ErrInvalidDirections))
+	_, err := resp.Route(1).Steps()
+	assert.True(t, errors.Is(err, am.ErrInvalidDirections))
+	_, err = resp.Route(1).Polyline()
+	assert.True(t, errors.Is(err, am.ErrInvalidDirections))
+
+	resp = loadDirectionsResponse(t)
+	resp.Steps[0].StepPathIndex = -1
+	assert.True(t, errors.Is(resp.Validate(), am.ErrInvalidDirections))
+	steps, err := resp.Route(0).Steps()
+	assert.NoError(t, err)
+	_, err = steps[0].Path()
+	assert.True(t, errors.Is(err, am.ErrInvalidDirections))
+}
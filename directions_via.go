@@ -0,0 +1,150 @@
+package am
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// DirectionsViaOptions configures DirectionsVia, the zero value is valid.
+// The fields are those of DirectionsRequest, applied to every leg.
+type DirectionsViaOptions struct {
+	Avoid []DirectionsAvoid
+
+	// The departure from the first stop. Each later leg departs when the
+	// previous one arrives, stops take no time. Now by default.
+	DepartureDate time.Time
+
+	Lang           language.Tag
+	SearchLocation *Location
+	SearchRegion   *Region
+	TransportType  TransportType
+	UserLocation   *Location
+}
+
+// LegError is the error of DirectionsVia when the directions of a leg
+// failed.
+type LegError struct {
+	// The index of the leg, leg i goes from stop i to stop i+1.
+	Leg int
+
+	Err error
+}
+
+func (e *LegError) Error() string {
+	return fmt.Sprintf("am: directions leg %d: %v", e.Leg, e.Err)
+}
+
+func (e *LegError) Unwrap() error { return e.Err }
+
+// DirectionsLeg is a leg of MultiStopDirections.
+type DirectionsLeg struct {
+	// The directions of the leg, as returned by Client.Directions. Its first
+	// route is the one followed.
+	Response *DirectionsResponse
+
+	// The indexes of the steps of the leg in the steps of
+	// MultiStopDirections.Directions.
+	StepIndexes []int64
+
+	DistanceMeters  int64
+	DurationSeconds int64
+}
+
+// MultiStopDirections is the result of DirectionsVia.
+type MultiStopDirections struct {
+	// The legs stitched together: the origin of the first leg, the
+	// destination of the last one, and a single route made of the steps of
+	// all the legs in order. The route covers the total distance and
+	// duration, and has tolls if any leg has.
+	//
+	// Route(0) gives its steps and polyline, ToGeoJSON and WriteGPX work as
+	// for a single request.
+	Directions *DirectionsResponse
+
+	// The legs, one less than the stops, or the ones before the failed leg
+	// with a *LegError.
+	Legs []DirectionsLeg
+}
+
+// DirectionsVia returns the directions through all the stops in order, one
+// client.Directions request per leg. The error of a failed leg is a
+// *LegError, returned with the legs before it stitched together, so they can
+// be reused.
+func DirectionsVia(ctx context.Context, client Client, stops []OneOfLoc, opts *DirectionsViaOptions) (*MultiStopDirections, error) {
+	if len(stops) < 2 {
+		return nil, errors.New("am: directions need at least 2 stops")
+	}
+	if opts == nil {
+		opts = &DirectionsViaOptions{}
+	}
+	result := &MultiStopDirections{
+		Directions: &DirectionsResponse{Routes: []DirectionsResponseRoute{{}}},
+		Legs:       make([]DirectionsLeg, 0, len(stops)-1),
+	}
+	combined := result.Directions
+	route := &combined.Routes[0]
+	var names []string
+	departure := opts.DepartureDate
+	for i := 0; i+1 < len(stops); i++ {
+		resp, err := client.Directions(ctx, &DirectionsRequest{
+			Origin:         stops[i],
+			Destination:    stops[i+1],
+			Avoid:          opts.Avoid,
+			DepartureDate:  departure,
+			Lang:           opts.Lang,
+			SearchLocation: opts.SearchLocation,
+			SearchRegion:   opts.SearchRegion,
+			TransportType:  opts.TransportType,
+			UserLocation:   opts.UserLocation,
+		})
+		if err == nil && len(resp.Routes) == 0 {
+			err = fmt.Errorf("%w: no route", ErrInvalidDirections)
+		}
+		if err == nil {
+			err = resp.Validate()
+		}
+		if err != nil {
+			route.Name = strings.Join(names, ", ")
+			return result, &LegError{Leg: i, Err: err}
+		}
+
+		legRoute := resp.Routes[0]
+		leg := DirectionsLeg{
+			Response:        resp,
+			StepIndexes:     make([]int64, 0, len(legRoute.StepIndexes)),
+			DistanceMeters:  legRoute.DistanceMeters,
+			DurationSeconds: legRoute.DurationSeconds,
+		}
+		for _, index := range legRoute.StepIndexes {
+			step := resp.Steps[index]
+			combined.StepPaths = append(combined.StepPaths, resp.StepPaths[step.StepPathIndex])
+			step.StepPathIndex = int64(len(combined.StepPaths) - 1)
+			combined.Steps = append(combined.Steps, step)
+			leg.StepIndexes = append(leg.StepIndexes, int64(len(combined.Steps)-1))
+		}
+		result.Legs = append(result.Legs, leg)
+
+		if i == 0 {
+			combined.Origin = resp.Origin
+			route.TransportType = legRoute.TransportType
+		}
+		combined.Destination = resp.Destination
+		route.StepIndexes = append(route.StepIndexes, leg.StepIndexes...)
+		route.DistanceMeters += legRoute.DistanceMeters
+		route.DurationSeconds += legRoute.DurationSeconds
+		route.HasTolls = route.HasTolls || legRoute.HasTolls
+		if legRoute.Name != "" {
+			names = append(names, legRoute.Name)
+		}
+		if !departure.IsZero() {
+			departure = departure.Add(time.Duration(legRoute.DurationSeconds) * time.Second)
+		}
+	}
+	route.Name = strings.Join(names, ", ")
+	return result, nil
+}
@@ -0,0 +1,141 @@
+package am_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+var viaStops = map[string]am.Location{
+	"A": {Latitude: 0, Longitude: 0},
+	"B": {Latitude: 0, Longitude: 1},
+	"C": {Latitude: 1, Longitude: 1},
+}
+
+// newDirectionsViaTestClient serves 2 routes per leg, the first one made of 2
+// steps through the midpoint, 1000 meters and 100 seconds each. Unknown stops
+// are unauthorized.
+func newDirectionsViaTestClient(t *testing.T, departures *[]string) am.Client {
+	return newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		*departures = append(*departures, query.Get("departureDate"))
+		origin, ok1 := viaStops[query.Get("origin")]
+		destination, ok2 := viaStops[query.Get("destination")]
+		if !ok1 || !ok2 {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write(expectErrorResponse1)
+			return
+		}
+		mid := am.Location{
+			Latitude:  (origin.Latitude + destination.Latitude) / 2,
+			Longitude: (origin.Longitude + destination.Longitude) / 2,
+		}
+		name := query.Get("origin") + query.Get("destination")
+		resp := am.DirectionsResponse{
+			Origin:      am.Place{Name: query.Get("origin"), Coordinate: origin},
+			Destination: am.Place{Name: query.Get("destination"), Coordinate: destination},
+			Routes: []am.DirectionsResponseRoute{
+				{Name: "alternate", StepIndexes: []int64{0}, DistanceMeters: 5000, DurationSeconds: 500},
+				{Name: name, StepIndexes: []int64{1, 2}, DistanceMeters: 2000, DurationSeconds: 200, HasTolls: name == "BC", TransportType: am.TransportTypeWalking},
+			},
+			StepPaths: [][]am.Location{{origin, destination}, {origin, mid}, {mid, destination}},
+			Steps: []am.DirectionsResponseStep{
+				{StepPathIndex: 0, Instructions: "alternate"},
+				{StepPathIndex: 1, Instructions: "start " + name, DistanceMeters: 1000, DurationSeconds: 100},
+				{StepPathIndex: 2, Instructions: "end " + name, DistanceMeters: 1000, DurationSeconds: 100},
+			},
+		}
+		// The first route is the one followed.
+		resp.Routes[0], resp.Routes[1] = resp.Routes[1], resp.Routes[0]
+		_ = json.NewEncoder(w).Encode(&resp)
+	})
+}
+
+func TestDirectionsVia(t *testing.T) {
+	var departures []string
+	client := newDirectionsViaTestClient(t, &departures)
+	stops := []am.OneOfLoc{{Address: "A"}, {Address: "B"}, {Address: "C"}, {Address: "A"}}
+	result, err := am.DirectionsVia(context.Background(), client, stops, &am.DirectionsViaOptions{
+		DepartureDate: time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC),
+		TransportType: am.TransportTypeWalking,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"2024-01-02T03:00:00Z", "2024-01-02T03:03:20Z", "2024-01-02T03:06:40Z"}, departures)
+
+	assert.Len(t, result.Legs, 3)
+	for i, leg := range result.Legs {
+		assert.Equal(t, int64(2000), leg.DistanceMeters)
+		assert.Equal(t, int64(200), leg.DurationSeconds)
+		assert.Equal(t, []int64{int64(2 * i), int64(2*i + 1)}, leg.StepIndexes)
+		assert.Equal(t, stops[i].Address, leg.Response.Origin.Name)
+	}
+
+	combined := result.Directions
+	assert.NoError(t, combined.Validate())
+	assert.Equal(t, "A", combined.Origin.Name)
+	assert.Equal(t, "A", combined.Destination.Name)
+	assert.Len(t, combined.Routes, 1)
+	assert.Len(t, combined.Steps, 6)
+	assert.Len(t, combined.StepPaths, 6)
+	route := combined.Route(0)
+	assert.Equal(t, "AB, BC, CA", route.Name)
+	assert.Equal(t, int64(6000), route.DistanceMeters)
+	assert.Equal(t, int64(600), route.DurationSeconds)
+	assert.True(t, route.HasTolls)
+	assert.Equal(t, am.TransportTypeWalking, route.TransportType)
+
+	steps, err := route.Steps()
+	assert.NoError(t, err)
+	assert.Equal(t, "start AB", steps[0].Instructions)
+	assert.Equal(t, "end CA", steps[5].Instructions)
+	polyline, err := route.Polyline()
+	assert.NoError(t, err)
+	assert.Equal(t, []am.Location{
+		viaStops["A"], {Latitude: 0, Longitude: 0.5},
+		viaStops["B"], {Latitude: 0.5, Longitude: 1},
+		viaStops["C"], {Latitude: 0.5, Longitude: 0.5},
+		viaStops["A"],
+	}, polyline)
+}
+
+func TestDirectionsVia_Errors(t *testing.T) {
+	var departures []string
+	client := newDirectionsViaTestClient(t, &departures)
+
+	result, err := am.DirectionsVia(context.Background(), client, []am.OneOfLoc{{Address: "A"}, {Address: "B"}, {Address: "X"}, {Address: "A"}}, nil)
+	var legErr *am.LegError
+	assert.True(t, errors.As(err, &legErr))
+	assert.Equal(t, 1, legErr.Leg)
+	var apiErr *am.ErrorFromAPI
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Len(t, departures, 2)
+	assert.Equal(t, "", departures[0])
+
+	// The legs before the failed one are kept.
+	assert.Len(t, result.Legs, 1)
+	assert.Equal(t, "A", result.Directions.Origin.Name)
+	assert.Equal(t, "B", result.Directions.Destination.Name)
+	assert.NoError(t, result.Directions.Validate())
+	route := result.Directions.Route(0)
+	assert.Equal(t, "AB", route.Name)
+	assert.Equal(t, int64(2000), route.DistanceMeters)
+	polyline, err := route.Polyline()
+	assert.NoError(t, err)
+	assert.Equal(t, []am.Location{viaStops["A"], {Latitude: 0, Longitude: 0.5}, viaStops["B"]}, polyline)
+
+	result, err = am.DirectionsVia(context.Background(), client, []am.OneOfLoc{{Address: "A"}, {}}, nil)
+	assert.True(t, errors.As(err, &legErr))
+	assert.Equal(t, 0, legErr.Leg)
+	assert.Empty(t, result.Legs)
+
+	_, err = am.DirectionsVia(context.Background(), client, []am.OneOfLoc{{Address: "A"}}, nil)
+	assert.Error(t, err)
+}
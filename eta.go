@@ -0,0 +1,165 @@
+package am
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// DefaultEtaMatchTolerance is the distance in meters within which
+// EtaByDestination matches an ETA to a requested destination. It absorbs the
+// rounding of the coordinates by Apple and the datum conversion of WithDatum.
+const DefaultEtaMatchTolerance = 10.0
+
+// ErrEtaMissing is the error of the cells of a TravelMatrix without ETA, see
+// EtaMatch.Missing.
+var ErrEtaMissing = errors.New("am: no ETA returned for the destination")
+
+// EtaMatch is the ETA of one requested destination.
+type EtaMatch struct {
+	// The index of the destination in EtaRequest.Destinations.
+	Index int
+
+	// The ETA of the destination, nil if Missing.
+	Eta *EtaResponseEta
+
+	// Apple returned no ETA for the destination, for example because it
+	// can't be reached with the transport type.
+	Missing bool
+}
+
+// Match pairs the requested destinations with the ETAs of the response,
+// whose destinations may be rounded and come in any order. An ETA is matched
+// to the destination it is the closest to, within tolerance meters, each ETA
+// to one destination at most. Matches are in the order of destinations.
+func (resp *EtaResponse) Match(destinations []Location, tolerance float64) []EtaMatch {
+	type pair struct {
+		destination, eta int
+		distance         float64
+	}
+	var pairs []pair
+	for i, destination := range destinations {
+		for k := range resp.Etas {
+			if d := destination.Distance(resp.Etas[k].Destination); d <= tolerance {
+				pairs = append(pairs, pair{i, k, d})
+			}
+		}
+	}
+	// Closest pairs first, ties in input order so that duplicated
+	// destinations get the ETAs in the order Apple returned them.
+	sort.SliceStable(pairs, func(a, b int) bool { return pairs[a].distance < pairs[b].distance })
+
+	matches := make([]EtaMatch, len(destinations))
+	for i := range matches {
+		matches[i] = EtaMatch{Index: i, Missing: true}
+	}
+	used := make([]bool, len(resp.Etas))
+	for _, p := range pairs {
+		if !matches[p.destination].Missing || used[p.eta] {
+			continue
+		}
+		used[p.eta] = true
+		matches[p.destination] = EtaMatch{Index: p.destination, Eta: &resp.Etas[p.eta]}
+	}
+	return matches
+}
+
+// EtaByDestination runs client.Eta and returns the ETAs of req.Destinations
+// in their order, matched with DefaultEtaMatchTolerance, see Match.
+func EtaByDestination(ctx context.Context, client Client, req *EtaRequest) ([]EtaMatch, error) {
+	resp, err := client.Eta(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Match(req.Destinations, DefaultEtaMatchTolerance), nil
+}
+
+// EtaManyOptions configures EtaMany, the zero value is valid.
+type EtaManyOptions struct {
+	// See EtaRequest.
+	TransportType TransportType
+	DepartureDate time.Time
+	ArrivalDate   time.Time
+
+	// The number of requests in flight, 4 by default.
+	Concurrency int
+}
+
+// EtaManyResult is the ETA to one destination of EtaMany.
+type EtaManyResult struct {
+	// The index of the destination.
+	Index int
+
+	// Nil if Err is set or Missing.
+	Eta *EtaResponseEta
+
+	// Apple returned no ETA for the destination, see EtaMatch.
+	Missing bool
+
+	Err error
+}
+
+// etaChunks splits destinations into requests of at most maxEtaDestinations.
+func etaChunks(origin Location, destinations []Location, opts *EtaManyOptions) []EtaRequest {
+	reqs := make([]EtaRequest, 0, (len(destinations)+maxEtaDestinations-1)/maxEtaDestinations)
+	for start := 0; start < len(destinations); start += maxEtaDestinations {
+		end := start + maxEtaDestinations
+		if end > len(destinations) {
+			end = len(destinations)
+		}
+		reqs = append(reqs, EtaRequest{
+			Origin:        &origin,
+			Destinations:  destinations[start:end],
+			TransportType: opts.TransportType,
+			DepartureDate: opts.DepartureDate,
+			ArrivalDate:   opts.ArrivalDate,
+		})
+	}
+	return reqs
+}
+
+// runEtaChunks runs the requests and returns the results of the
+// destinations of every request, indexed by their position in the request.
+func runEtaChunks(ctx context.Context, client Client, reqs []EtaRequest, concurrency int) [][]EtaManyResult {
+	chunks := runBatch(ctx, reqs, &BatchOptions[EtaResponse]{Concurrency: concurrency}, client.Eta)
+	results := make([][]EtaManyResult, len(reqs))
+	for i, chunk := range chunks {
+		results[i] = matchEtas(reqs[i].Destinations, chunk.Response, chunk.Err)
+	}
+	return results
+}
+
+// matchEtas returns the results of the destinations of a request, err is the
+// error of the request. ETAs are matched with DefaultEtaMatchTolerance.
+func matchEtas(destinations []Location, resp *EtaResponse, err error) []EtaManyResult {
+	results := make([]EtaManyResult, len(destinations))
+	for i := range results {
+		results[i] = EtaManyResult{Index: i, Err: err}
+	}
+	if err != nil {
+		return results
+	}
+	for _, match := range resp.Match(destinations, DefaultEtaMatchTolerance) {
+		results[match.Index].Eta = match.Eta
+		results[match.Index].Missing = match.Missing
+	}
+	return results
+}
+
+// EtaMany returns the ETAs from origin to any number of destinations, split
+// into requests of 10 destinations to client.Eta. Results are in the order of
+// destinations with an error per destination.
+func EtaMany(ctx context.Context, client Client, origin Location, destinations []Location, opts *EtaManyOptions) []EtaManyResult {
+	if opts == nil {
+		opts = &EtaManyOptions{}
+	}
+	results := make([]EtaManyResult, 0, len(destinations))
+	for _, chunk := range runEtaChunks(ctx, client, etaChunks(origin, destinations, opts), opts.Concurrency) {
+		for _, result := range chunk {
+			result.Index = len(results)
+			results = append(results, result)
+		}
+	}
+	return results
+}
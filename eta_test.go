@@ -0,0 +1,193 @@
+package am_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	am "github.com/ringsaturn/am"
+	"github.com/ringsaturn/am/mockclient"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEtaResponse_Match(t *testing.T) {
+	store1 := am.Location{Latitude: 37.32556561130194, Longitude: -121.94635203581443}
+	store2 := am.Location{Latitude: 37.44176585512703, Longitude: -122.17259315798667}
+	store3 := am.Location{Latitude: 37.4, Longitude: -122}
+	resp := &am.EtaResponse{Etas: []am.EtaResponseEta{
+		// Reordered and rounded to 5 decimals, about a meter.
+		{Destination: am.Location{Latitude: 37.44177, Longitude: -122.17259}, DistanceMeters: 2},
+		{Destination: am.Location{Latitude: 37.32557, Longitude: -121.94635}, DistanceMeters: 1},
+		// Nothing requested there.
+		{Destination: am.Location{Latitude: 0, Longitude: 0}, DistanceMeters: 99},
+	}}
+
+	matches := resp.Match([]am.Location{store1, store2, store3}, am.DefaultEtaMatchTolerance)
+	assert.Len(t, matches, 3)
+	assert.Equal(t, 0, matches[0].Index)
+	assert.False(t, matches[0].Missing)
+	assert.Equal(t, int64(1), matches[0].Eta.DistanceMeters)
+	assert.Equal(t, 1, matches[1].Index)
+	assert.Equal(t, int64(2), matches[1].Eta.DistanceMeters)
+	assert.Equal(t, am.EtaMatch{Index: 2, Missing: true}, matches[2])
+
+	// Out of tolerance.
+	matches = resp.Match([]am.Location{store1}, 0.1)
+	assert.True(t, matches[0].Missing)
+
+	// The closest destination wins, the other one is missing.
+	near := am.Location{Latitude: 37.32557, Longitude: -121.94635}
+	matches = resp.Match([]am.Location{store1, near}, am.DefaultEtaMatchTolerance)
+	assert.True(t, matches[0].Missing)
+	assert.Equal(t, int64(1), matches[1].Eta.DistanceMeters)
+
+	// Duplicated destinations get one ETA each, in order.
+	dup := &am.EtaResponse{Etas: []am.EtaResponseEta{
+		{Destination: store1, DistanceMeters: 1},
+		{Destination: store1, DistanceMeters: 2},
+	}}
+	matches = dup.Match([]am.Location{store1, store1, store1}, am.DefaultEtaMatchTolerance)
+	assert.Equal(t, int64(1), matches[0].Eta.DistanceMeters)
+	assert.Equal(t, int64(2), matches[1].Eta.DistanceMeters)
+	assert.True(t, matches[2].Missing)
+
+	assert.Empty(t, resp.Match(nil, am.DefaultEtaMatchTolerance))
+}
+
+func TestEtaByDestination(t *testing.T) {
+	client := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(expectEtaResponse1)
+	})
+	// Requested in the other order, with a third destination.
+	req := &am.EtaRequest{
+		Origin: &am.Location{Latitude: 37.331423, Longitude: -122.030503},
+		Destinations: []am.Location{
+			{Latitude: 37.441766, Longitude: -122.172593},
+			{Latitude: 37.325566, Longitude: -121.946352},
+			{Latitude: 37.5, Longitude: -122.2},
+		},
+	}
+	matches, err := am.EtaByDestination(context.Background(), client, req)
+	assert.NoError(t, err)
+	assert.Len(t, matches, 3)
+	assert.Equal(t, int64(20942), matches[0].Eta.DistanceMeters)
+	assert.Equal(t, int64(12534), matches[1].Eta.DistanceMeters)
+	assert.True(t, matches[2].Missing)
+
+	client = newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write(expectErrorResponse1)
+	})
+	matches, err = am.EtaByDestination(context.Background(), client, req)
+	assert.Error(t, err)
+	assert.Nil(t, matches)
+}
+
+func TestEtaMany(t *testing.T) {
+	var requests atomic.Int64
+	client := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		query := r.URL.Query()
+		assert.Equal(t, "37.331423,-122.030503", query.Get("origin"))
+		assert.Equal(t, "Walking", query.Get("transportType"))
+		destinations := strings.Split(query.Get("destinations"), "|")
+		assert.LessOrEqual(t, len(destinations), 10)
+
+		etas := []map[string]any{}
+		for i := len(destinations) - 1; i >= 0; i-- {
+			loc, err := am.ParseLocation(destinations[i])
+			assert.NoError(t, err)
+			switch loc.Latitude {
+			case 13:
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write(expectErrorResponse1)
+				return
+			case 7:
+				// Unreachable, Apple leaves it out.
+				continue
+			}
+			etas = append(etas, map[string]any{
+				"destination":               loc,
+				"distanceMeters":            int(loc.Latitude) * 1000,
+				"expectedTravelTimeSeconds": int(loc.Latitude) * 60,
+				"transportType":             "WALKING",
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"etas": etas})
+	})
+
+	origin := am.Location{Latitude: 37.331423, Longitude: -122.030503}
+	destinations := make([]am.Location, 25)
+	for i := range destinations {
+		destinations[i] = am.Location{Latitude: float64(i), Longitude: -122}
+	}
+	results := am.EtaMany(context.Background(), client, origin, destinations, &am.EtaManyOptions{
+		TransportType: am.TransportTypeWalking,
+		Concurrency:   2,
+	})
+	assert.Equal(t, int64(3), requests.Load())
+	assert.Len(t, results, len(destinations))
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		switch {
+		case i >= 10 && i < 20:
+			var apiErr *am.ErrorFromAPI
+			assert.True(t, errors.As(result.Err, &apiErr), i)
+			assert.Nil(t, result.Eta)
+		case i == 7:
+			assert.NoError(t, result.Err)
+			assert.True(t, result.Missing)
+			assert.Nil(t, result.Eta)
+		default:
+			assert.NoError(t, result.Err, i)
+			assert.False(t, result.Missing)
+			assert.Equal(t, destinations[i], result.Eta.Destination)
+			assert.Equal(t, int64(i)*1000, result.Eta.DistanceMeters)
+		}
+	}
+
+	assert.Empty(t, am.EtaMany(context.Background(), client, origin, nil, nil))
+
+	results = am.EtaMany(context.Background(), client, origin, destinations[:2], &am.EtaManyOptions{TransportType: "Flying"})
+	for _, result := range results {
+		assert.Error(t, result.Err)
+	}
+}
+
+func TestEtaMany_Rounded(t *testing.T) {
+	client := mockclient.NewMockClient(gomock.NewController(t))
+	// Apple rounds the destinations and returns them in any order.
+	client.EXPECT().Eta(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *am.EtaRequest) (*am.EtaResponse, error) {
+			resp := &am.EtaResponse{}
+			for i := len(req.Destinations) - 1; i >= 0; i-- {
+				d := req.Destinations[i]
+				resp.Etas = append(resp.Etas, am.EtaResponseEta{
+					Destination: am.Location{
+						Latitude:  math.Round(d.Latitude*1e5) / 1e5,
+						Longitude: math.Round(d.Longitude*1e5) / 1e5,
+					},
+					DistanceMeters: int64(i),
+				})
+			}
+			return resp, nil
+		},
+	).Times(2)
+
+	destinations := make([]am.Location, 12)
+	for i := range destinations {
+		destinations[i] = am.Location{Latitude: 37.123456789 + float64(i)/100, Longitude: -122.987654321}
+	}
+	results := am.EtaMany(context.Background(), client, am.Location{}, destinations, nil)
+	for i, result := range results {
+		assert.NoError(t, result.Err)
+		assert.False(t, result.Missing, i)
+		assert.Equal(t, int64(i%10), result.Eta.DistanceMeters, i)
+	}
+}
@@ -0,0 +1,243 @@
+package am
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// WGS-84 ellipsoid, for VincentyDistance.
+const (
+	wgs84SemiMajorAxis = 6378137.0
+	wgs84Flattening    = 1 / 298.257223563
+)
+
+// ErrVincentyNotConverged is returned by VincentyDistance for nearly
+// antipodal points, where the formula doesn't converge.
+var ErrVincentyNotConverged = errors.New("am: Vincenty formula failed to converge")
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func toDegrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// normalizeLongitude wraps lon into [-180, 180].
+func normalizeLongitude(lon float64) float64 {
+	return math.Remainder(lon, 360)
+}
+
+// eastwardDegrees returns the degrees to travel east from lon `from` to lon
+// `to`, in [0, 360).
+func eastwardDegrees(from, to float64) float64 {
+	d := math.Mod(to-from, 360)
+	if d < 0 {
+		d += 360
+	}
+	return d
+}
+
+// Distance returns the great-circle distance to other in metres, with the
+// haversine formula on a sphere. It's within 0.5% of the distance on the
+// ellipsoid, use VincentyDistance when that matters.
+func (location Location) Distance(other Location) float64 {
+	lat1, lat2 := toRadians(location.Latitude), toRadians(other.Latitude)
+	dLat := lat2 - lat1
+	dLon := toRadians(other.Longitude - location.Longitude)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// VincentyDistance returns the distance to other in metres on the WGS-84
+// ellipsoid, accurate to the millimetre. It fails with
+// ErrVincentyNotConverged for nearly antipodal points.
+func (location Location) VincentyDistance(other Location) (float64, error) {
+	const b = wgs84SemiMajorAxis * (1 - wgs84Flattening)
+	f := wgs84Flattening
+
+	L := toRadians(other.Longitude - location.Longitude)
+	U1 := math.Atan((1 - f) * math.Tan(toRadians(location.Latitude)))
+	U2 := math.Atan((1 - f) * math.Tan(toRadians(other.Latitude)))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	lambda := L
+	var sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM float64
+	for i := 0; ; i++ {
+		if i == 200 {
+			return 0, ErrVincentyNotConverged
+		}
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			return 0, nil
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cosSqAlpha != 0 {
+			// Not on the equator.
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+		C := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = L + (1-C)*f*sinAlpha*(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-prev) < 1e-12 {
+			break
+		}
+	}
+
+	uSq := cosSqAlpha * (wgs84SemiMajorAxis*wgs84SemiMajorAxis - b*b) / (b * b)
+	A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+	return b * A * (sigma - deltaSigma), nil
+}
+
+// Bearing returns the initial bearing of the great circle to other, in
+// degrees clockwise from north in [0, 360).
+func (location Location) Bearing(other Location) float64 {
+	lat1, lat2 := toRadians(location.Latitude), toRadians(other.Latitude)
+	dLon := toRadians(other.Longitude - location.Longitude)
+	y := math.Sin(dLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+	return eastwardDegrees(0, toDegrees(math.Atan2(y, x)))
+}
+
+// Destination returns the point reached after travelling distance metres
+// along the great circle starting at the bearing, in degrees clockwise from
+// north.
+func (location Location) Destination(bearing, distance float64) Location {
+	lat1, lon1 := toRadians(location.Latitude), toRadians(location.Longitude)
+	theta := toRadians(bearing)
+	delta := distance / earthRadiusMeters
+	sinLat := math.Sin(lat1)*math.Cos(delta) + math.Cos(lat1)*math.Sin(delta)*math.Cos(theta)
+	lat2 := math.Asin(math.Max(-1, math.Min(1, sinLat)))
+	lon2 := lon1 + math.Atan2(
+		math.Sin(theta)*math.Sin(delta)*math.Cos(lat1),
+		math.Cos(delta)-math.Sin(lat1)*sinLat,
+	)
+	return Location{Latitude: toDegrees(lat2), Longitude: normalizeLongitude(toDegrees(lon2))}
+}
+
+// longitudeSpan returns the degrees from the west to the east longitude.
+//
+// A region crosses the antimeridian when its west longitude is greater than
+// its east longitude. A region covering all longitudes goes from -180 to 180.
+func (region Region) longitudeSpan() float64 {
+	if region.WestLongitude == -180 && region.EastLongitude == 180 {
+		return 360
+	}
+	return eastwardDegrees(region.WestLongitude, region.EastLongitude)
+}
+
+// regionFromSpan returns the region of the latitudes and longitudes, or of
+// all longitudes when span, the degrees from west to east, reaches 360.
+func regionFromSpan(south, north, west, east, span float64) Region {
+	region := Region{SouthLatitude: south, NorthLatitude: north}
+	if span >= 360 {
+		region.WestLongitude, region.EastLongitude = -180, 180
+		return region
+	}
+	region.WestLongitude = normalizeLongitude(west)
+	region.EastLongitude = normalizeLongitude(east)
+	return region
+}
+
+// Contains reports whether the point is in the region, borders included.
+func (region Region) Contains(location Location) bool {
+	if location.Latitude < region.SouthLatitude || location.Latitude > region.NorthLatitude {
+		return false
+	}
+	return eastwardDegrees(region.WestLongitude, location.Longitude) <= region.longitudeSpan()
+}
+
+// Union returns the smallest region containing both regions. Of the two ways
+// around the globe, the narrower one is used.
+func (region Region) Union(other Region) Region {
+	south := math.Min(region.SouthLatitude, other.SouthLatitude)
+	north := math.Max(region.NorthLatitude, other.NorthLatitude)
+
+	spanA, spanB := region.longitudeSpan(), other.longitudeSpan()
+	// Start from the west of one region and go east until the other is
+	// covered.
+	union := func(a, b Region, spanA, spanB float64) (Region, float64) {
+		if span := eastwardDegrees(a.WestLongitude, b.WestLongitude) + spanB; span > spanA {
+			return regionFromSpan(south, north, a.WestLongitude, b.EastLongitude, span), span
+		}
+		return regionFromSpan(south, north, a.WestLongitude, a.EastLongitude, spanA), spanA
+	}
+	fromA, spanFromA := union(region, other, spanA, spanB)
+	fromB, spanFromB := union(other, region, spanB, spanA)
+	if spanFromA <= spanFromB {
+		return fromA
+	}
+	return fromB
+}
+
+// Expand returns the region grown by distance metres in every direction.
+// Latitudes stop at the poles, and the region covers all longitudes once it
+// reaches one.
+func (region Region) Expand(distance float64) Region {
+	dLat := toDegrees(distance / earthRadiusMeters)
+	south := math.Max(-90, region.SouthLatitude-dLat)
+	north := math.Min(90, region.NorthLatitude+dLat)
+	if south == -90 || north == 90 {
+		return regionFromSpan(south, north, -180, 180, 360)
+	}
+	// Longitude degrees are the shortest on the parallel farthest from the
+	// equator.
+	cos := math.Cos(toRadians(math.Max(math.Abs(south), math.Abs(north))))
+	dLon := toDegrees(distance / (earthRadiusMeters * cos))
+	return regionFromSpan(south, north, region.WestLongitude-dLon, region.EastLongitude+dLon, region.longitudeSpan()+2*dLon)
+}
+
+// Center returns the point halfway between the borders of the region.
+func (region Region) Center() Location {
+	return Location{
+		Latitude:  (region.SouthLatitude + region.NorthLatitude) / 2,
+		Longitude: normalizeLongitude(region.WestLongitude + region.longitudeSpan()/2),
+	}
+}
+
+// RegionAround returns a region containing every point within radius metres
+// of center.
+func RegionAround(center Location, radius float64) Region {
+	return BoundingRegion([]Location{center}).Expand(radius)
+}
+
+// BoundingRegion returns the smallest region containing the points, the
+// zero Region if there is none.
+//
+// The region crosses the antimeridian when that's narrower, for example for
+// points at 179° and -179° of longitude.
+func BoundingRegion(locations []Location) Region {
+	if len(locations) == 0 {
+		return Region{}
+	}
+	south, north := 90.0, -90.0
+	lons := make([]float64, 0, len(locations))
+	for _, location := range locations {
+		south = math.Min(south, location.Latitude)
+		north = math.Max(north, location.Latitude)
+		lons = append(lons, normalizeLongitude(location.Longitude))
+	}
+	sort.Float64s(lons)
+
+	// The region is everything but the largest gap between two consecutive
+	// longitudes, starting with the gap across the antimeridian.
+	gapEnd := 0
+	gap := lons[0] + 360 - lons[len(lons)-1]
+	for i := 1; i < len(lons); i++ {
+		if d := lons[i] - lons[i-1]; d > gap {
+			gap, gapEnd = d, i
+		}
+	}
+	gapStart := (gapEnd + len(lons) - 1) % len(lons)
+	return regionFromSpan(south, north, lons[gapEnd], lons[gapStart], 360-gap)
+}
@@ -0,0 +1,168 @@
+package am_test
+
+import (
+	"math"
+	"testing"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	northPole = am.Location{Latitude: 90, Longitude: 0}
+	southPole = am.Location{Latitude: -90, Longitude: 0}
+)
+
+func TestLocation_Distance(t *testing.T) {
+	paris := am.Location{Latitude: 48.8566, Longitude: 2.3522}
+	london := am.Location{Latitude: 51.5074, Longitude: -0.1278}
+	assert.InDelta(t, 343_500, paris.Distance(london), 1_000)
+	assert.Zero(t, paris.Distance(paris))
+
+	// Across the dateline, the short way.
+	a := am.Location{Latitude: 0, Longitude: 179.5}
+	b := am.Location{Latitude: 0, Longitude: -179.5}
+	assert.InDelta(t, 111_195, a.Distance(b), 1)
+
+	// Every meridian meets at the poles.
+	assert.InDelta(t, math.Pi*6371008.8, northPole.Distance(southPole), 1e-6)
+	assert.InDelta(t, 0, northPole.Distance(am.Location{Latitude: 90, Longitude: 123}), 1e-6)
+}
+
+func TestLocation_VincentyDistance(t *testing.T) {
+	// Flinders Peak to Buninyong, the example of Vincenty's paper.
+	flinders := am.Location{Latitude: -37.95103341666667, Longitude: 144.42486788888889}
+	buninyong := am.Location{Latitude: -37.65282113888889, Longitude: 143.92649552777777}
+	d, err := flinders.VincentyDistance(buninyong)
+	assert.NoError(t, err)
+	assert.InDelta(t, 54972.271, d, 1e-3)
+
+	a := am.Location{Latitude: 0, Longitude: 179.5}
+	b := am.Location{Latitude: 0, Longitude: -179.5}
+	d, err = a.VincentyDistance(b)
+	assert.NoError(t, err)
+	assert.InDelta(t, 111_319.491, d, 1e-3)
+
+	d, err = northPole.VincentyDistance(southPole)
+	assert.NoError(t, err)
+	assert.InDelta(t, 20_003_931.459, d, 1e-3)
+
+	d, err = flinders.VincentyDistance(flinders)
+	assert.NoError(t, err)
+	assert.Zero(t, d)
+
+	_, err = am.Location{Latitude: 0, Longitude: 0}.VincentyDistance(am.Location{Latitude: 0.5, Longitude: 179.7})
+	assert.ErrorIs(t, err, am.ErrVincentyNotConverged)
+}
+
+func TestLocation_BearingAndDestination(t *testing.T) {
+	origin := am.Location{Latitude: 0, Longitude: 0}
+	assert.InDelta(t, 0, origin.Bearing(am.Location{Latitude: 1, Longitude: 0}), 1e-9)
+	assert.InDelta(t, 90, origin.Bearing(am.Location{Latitude: 0, Longitude: 1}), 1e-9)
+	assert.InDelta(t, 270, origin.Bearing(am.Location{Latitude: 0, Longitude: -1}), 1e-9)
+
+	// East across the dateline.
+	assert.InDelta(t, 90, am.Location{Latitude: 0, Longitude: 179.5}.Bearing(am.Location{Latitude: 0, Longitude: -179.5}), 1e-9)
+	// Everything is south of the north pole.
+	assert.InDelta(t, 180, northPole.Bearing(am.Location{Latitude: 10, Longitude: 0}), 1e-9)
+
+	dest := am.Location{Latitude: 0, Longitude: 179.5}.Destination(90, 111_195.08)
+	assert.InDelta(t, 0, dest.Latitude, 1e-9)
+	assert.InDelta(t, -179.5, dest.Longitude, 1e-6)
+
+	dest = am.Location{Latitude: 89, Longitude: 0}.Destination(0, 2*111_195.08)
+	assert.InDelta(t, 89, dest.Latitude, 1e-6)
+	assert.InDelta(t, 180, math.Abs(dest.Longitude), 1e-6)
+
+	paris := am.Location{Latitude: 48.8566, Longitude: 2.3522}
+	london := am.Location{Latitude: 51.5074, Longitude: -0.1278}
+	dest = paris.Destination(paris.Bearing(london), paris.Distance(london))
+	assert.InDelta(t, london.Latitude, dest.Latitude, 1e-9)
+	assert.InDelta(t, london.Longitude, dest.Longitude, 1e-9)
+}
+
+func TestRegion_Contains(t *testing.T) {
+	region := am.Region{NorthLatitude: 10, SouthLatitude: -10, WestLongitude: 170, EastLongitude: -170}
+	assert.True(t, region.Contains(am.Location{Latitude: 0, Longitude: 180}))
+	assert.True(t, region.Contains(am.Location{Latitude: 0, Longitude: -180}))
+	assert.True(t, region.Contains(am.Location{Latitude: 10, Longitude: -170}))
+	assert.False(t, region.Contains(am.Location{Latitude: 0, Longitude: 0}))
+	assert.False(t, region.Contains(am.Location{Latitude: 11, Longitude: 175}))
+
+	world := am.Region{NorthLatitude: 90, SouthLatitude: -90, WestLongitude: -180, EastLongitude: 180}
+	assert.True(t, world.Contains(northPole))
+	assert.True(t, world.Contains(am.Location{Latitude: 0, Longitude: 42}))
+}
+
+func TestRegion_Union(t *testing.T) {
+	a := am.Region{NorthLatitude: 1, SouthLatitude: 0, WestLongitude: 170, EastLongitude: 175}
+	b := am.Region{NorthLatitude: 2, SouthLatitude: -1, WestLongitude: -175, EastLongitude: -170}
+	assert.Equal(t, am.Region{NorthLatitude: 2, SouthLatitude: -1, WestLongitude: 170, EastLongitude: -170}, a.Union(b))
+	assert.Equal(t, a.Union(b), b.Union(a))
+
+	c := am.Region{NorthLatitude: 1, SouthLatitude: 0, WestLongitude: 10, EastLongitude: 20}
+	d := am.Region{NorthLatitude: 1, SouthLatitude: 0, WestLongitude: 12, EastLongitude: 15}
+	assert.Equal(t, c, c.Union(d))
+
+	e := am.Region{NorthLatitude: 1, SouthLatitude: 0, WestLongitude: -10, EastLongitude: 0}
+	assert.Equal(t, am.Region{NorthLatitude: 1, SouthLatitude: 0, WestLongitude: -10, EastLongitude: 20}, c.Union(e))
+
+	halves := am.Region{NorthLatitude: 1, SouthLatitude: 0, WestLongitude: 0, EastLongitude: 180}.
+		Union(am.Region{NorthLatitude: 1, SouthLatitude: 0, WestLongitude: -180, EastLongitude: 0})
+	assert.Equal(t, am.Region{NorthLatitude: 1, SouthLatitude: 0, WestLongitude: -180, EastLongitude: 180}, halves)
+}
+
+func TestRegion_ExpandAndCenter(t *testing.T) {
+	region := am.Region{NorthLatitude: 1, SouthLatitude: -1, WestLongitude: 179, EastLongitude: -179}
+	assert.Equal(t, am.Location{Latitude: 0, Longitude: 180}, region.Center())
+
+	expanded := region.Expand(111_195.08)
+	assert.InDelta(t, 2, expanded.NorthLatitude, 1e-6)
+	assert.InDelta(t, -2, expanded.SouthLatitude, 1e-6)
+	assert.InDelta(t, 178, expanded.WestLongitude, 0.01)
+	assert.InDelta(t, -178, expanded.EastLongitude, 0.01)
+
+	polar := am.Region{NorthLatitude: 89.5, SouthLatitude: 89, WestLongitude: 10, EastLongitude: 20}.Expand(100_000)
+	assert.Equal(t, am.Region{NorthLatitude: 90, SouthLatitude: polar.SouthLatitude, WestLongitude: -180, EastLongitude: 180}, polar)
+	assert.Equal(t, am.Location{Latitude: 45, Longitude: 0}, am.Region{NorthLatitude: 90, SouthLatitude: 0, WestLongitude: -180, EastLongitude: 180}.Center())
+}
+
+func TestRegionAround(t *testing.T) {
+	center := am.Location{Latitude: 45, Longitude: 179.99}
+	region := am.RegionAround(center, 5_000)
+	assert.Greater(t, region.WestLongitude, region.EastLongitude)
+	for bearing := 0.0; bearing < 360; bearing += 15 {
+		assert.True(t, region.Contains(center.Destination(bearing, 4_999)), "bearing %v", bearing)
+	}
+	assert.False(t, region.Contains(center.Destination(0, 6_000)))
+
+	region = am.RegionAround(southPole, 1_000)
+	assert.Equal(t, -90.0, region.SouthLatitude)
+	assert.Equal(t, -180.0, region.WestLongitude)
+	assert.Equal(t, 180.0, region.EastLongitude)
+}
+
+func TestBoundingRegion(t *testing.T) {
+	assert.Equal(t, am.Region{}, am.BoundingRegion(nil))
+
+	point := am.Location{Latitude: 1, Longitude: 2}
+	assert.Equal(t, am.Region{NorthLatitude: 1, SouthLatitude: 1, WestLongitude: 2, EastLongitude: 2}, am.BoundingRegion([]am.Location{point}))
+
+	pacific := am.BoundingRegion([]am.Location{
+		{Latitude: -10, Longitude: 179},
+		{Latitude: 5, Longitude: -179},
+		{Latitude: 0, Longitude: 178},
+	})
+	assert.Equal(t, am.Region{NorthLatitude: 5, SouthLatitude: -10, WestLongitude: 178, EastLongitude: -179}, pacific)
+
+	europe := am.BoundingRegion([]am.Location{
+		{Latitude: 48.8566, Longitude: 2.3522},
+		{Latitude: 51.5074, Longitude: -0.1278},
+		{Latitude: 52.52, Longitude: 13.405},
+	})
+	assert.Equal(t, am.Region{NorthLatitude: 52.52, SouthLatitude: 48.8566, WestLongitude: -0.1278, EastLongitude: 13.405}, europe)
+
+	poles := am.BoundingRegion([]am.Location{northPole, southPole})
+	assert.Equal(t, 90.0, poles.NorthLatitude)
+	assert.Equal(t, -90.0, poles.SouthLatitude)
+}
@@ -0,0 +1,302 @@
+package am
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// MaxGeohashPrecision is the longest geohash supported, its cells are a few
+// centimetres wide.
+const MaxGeohashPrecision = 12
+
+// maxGeohashCovering bounds the number of geohashes GeohashCovering returns.
+const maxGeohashCovering = 1 << 16
+
+// ErrInvalidGeohash is wrapped by the errors of DecodeGeohash.
+var ErrInvalidGeohash = errors.New("am: invalid geohash")
+
+// geohashIndex maps the characters of geohashAlphabet to their value, -1 for
+// other characters.
+var geohashIndex = func() [256]int8 {
+	var index [256]int8
+	for i := range index {
+		index[i] = -1
+	}
+	for i := 0; i < len(geohashAlphabet); i++ {
+		index[geohashAlphabet[i]] = int8(i)
+	}
+	return index
+}()
+
+func checkGeohashPrecision(precision int) error {
+	if precision < 1 || precision > MaxGeohashPrecision {
+		return fmt.Errorf("am: geohash precision %d out of [1, %d]", precision, MaxGeohashPrecision)
+	}
+	return nil
+}
+
+// geohashCellSize returns the size in degrees of the cells of the precision.
+func geohashCellSize(precision int) (width, height float64) {
+	bits := 5 * precision
+	lonBits := (bits + 1) / 2
+	latBits := bits / 2
+	return 360 / math.Exp2(float64(lonBits)), 180 / math.Exp2(float64(latBits))
+}
+
+// EncodeGeohash returns the geohash of the point, precision characters long.
+func EncodeGeohash(location Location, precision int) (string, error) {
+	if err := checkGeohashPrecision(precision); err != nil {
+		return "", err
+	}
+	if err := checkLocationRange(location); err != nil {
+		return "", fmt.Errorf("am: can't encode geohash: %v", err)
+	}
+	south, north := -90.0, 90.0
+	west, east := -180.0, 180.0
+	even := true
+	sb := strings.Builder{}
+	sb.Grow(precision)
+	for sb.Len() < precision {
+		var v byte
+		for bit := 0; bit < 5; bit++ {
+			v <<= 1
+			if even {
+				mid := (west + east) / 2
+				if location.Longitude >= mid {
+					v |= 1
+					west = mid
+				} else {
+					east = mid
+				}
+			} else {
+				mid := (south + north) / 2
+				if location.Latitude >= mid {
+					v |= 1
+					south = mid
+				} else {
+					north = mid
+				}
+			}
+			even = !even
+		}
+		sb.WriteByte(geohashAlphabet[v])
+	}
+	return sb.String(), nil
+}
+
+// DecodeGeohash returns the cell of the geohash, case-insensitive.
+func DecodeGeohash(hash string) (Region, error) {
+	if hash == "" {
+		return Region{}, fmt.Errorf("%w: empty", ErrInvalidGeohash)
+	}
+	if len(hash) > MaxGeohashPrecision {
+		return Region{}, fmt.Errorf("%w %q: longer than %d", ErrInvalidGeohash, hash, MaxGeohashPrecision)
+	}
+	south, north := -90.0, 90.0
+	west, east := -180.0, 180.0
+	even := true
+	for i := 0; i < len(hash); i++ {
+		c := hash[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		v := geohashIndex[c]
+		if v < 0 {
+			return Region{}, fmt.Errorf("%w %q: invalid character %q at %d", ErrInvalidGeohash, hash, hash[i], i)
+		}
+		for bit := 4; bit >= 0; bit-- {
+			set := v>>bit&1 == 1
+			if even {
+				mid := (west + east) / 2
+				if set {
+					west = mid
+				} else {
+					east = mid
+				}
+			} else {
+				mid := (south + north) / 2
+				if set {
+					south = mid
+				} else {
+					north = mid
+				}
+			}
+			even = !even
+		}
+	}
+	return Region{EastLongitude: east, NorthLatitude: north, SouthLatitude: south, WestLongitude: west}, nil
+}
+
+// GeohashNeighbors returns the 8 geohashes around hash, in the order north,
+// north-east, east, south-east, south, south-west, west and north-west.
+//
+// Neighbours wrap around the antimeridian. There is nothing north of the
+// cells touching the north pole, nor south of the ones touching the south
+// pole, such neighbours are "".
+func GeohashNeighbors(hash string) ([8]string, error) {
+	var neighbors [8]string
+	cell, err := DecodeGeohash(hash)
+	if err != nil {
+		return neighbors, err
+	}
+	width, height := geohashCellSize(len(hash))
+	center := cell.Center()
+	offsets := [8][2]float64{{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1}}
+	for i, offset := range offsets {
+		lat := center.Latitude + offset[0]*height
+		if lat < -90 || lat > 90 {
+			continue
+		}
+		lon := normalizeLongitude(center.Longitude + offset[1]*width)
+		neighbors[i], _ = EncodeGeohash(Location{Latitude: lat, Longitude: lon}, len(hash))
+	}
+	return neighbors, nil
+}
+
+// GeohashCovering returns the geohashes of the precision whose cells cover
+// the region, regions crossing the antimeridian included. Coverings of more
+// than 65536 cells are refused, pick a lower precision for large regions.
+func GeohashCovering(region Region, precision int) ([]string, error) {
+	if err := checkGeohashPrecision(precision); err != nil {
+		return nil, err
+	}
+	if region.SouthLatitude > region.NorthLatitude {
+		return nil, fmt.Errorf("am: region south latitude %v is north of its north latitude %v",
+			region.SouthLatitude, region.NorthLatitude)
+	}
+	width, height := geohashCellSize(precision)
+	columns := int(math.Round(360 / width))
+	rows := int(math.Round(180 / height))
+	index := func(v, min, size float64, count int) int {
+		return int(math.Max(0, math.Min(float64(count-1), math.Floor((v-min)/size))))
+	}
+
+	type span struct{ first, last int }
+	var spans []span
+	switch {
+	case region.longitudeSpan() >= 360:
+		spans = []span{{0, columns - 1}}
+	case region.WestLongitude > region.EastLongitude:
+		spans = []span{
+			{index(region.WestLongitude, -180, width, columns), columns - 1},
+			{0, index(region.EastLongitude, -180, width, columns)},
+		}
+	default:
+		spans = []span{{
+			index(region.WestLongitude, -180, width, columns),
+			index(region.EastLongitude, -180, width, columns),
+		}}
+	}
+	firstRow := index(region.SouthLatitude, -90, height, rows)
+	lastRow := index(region.NorthLatitude, -90, height, rows)
+
+	count := 0
+	for _, s := range spans {
+		count += (s.last - s.first + 1) * (lastRow - firstRow + 1)
+	}
+	if count > maxGeohashCovering {
+		return nil, fmt.Errorf("am: covering the region needs %d geohashes, more than %d", count, maxGeohashCovering)
+	}
+	hashes := make([]string, 0, count)
+	for row := firstRow; row <= lastRow; row++ {
+		lat := -90 + (float64(row)+0.5)*height
+		for _, s := range spans {
+			for column := s.first; column <= s.last; column++ {
+				lon := -180 + (float64(column)+0.5)*width
+				hash, _ := EncodeGeohash(Location{Latitude: lat, Longitude: lon}, precision)
+				hashes = append(hashes, hash)
+			}
+		}
+	}
+	return hashes, nil
+}
+
+// Cell is a cell of a hierarchical grid over the Earth, to bucket points for
+// deduplication, caching or clustering. Cells are comparable and can be used
+// as map keys.
+//
+// Cells are geohashes, their level is the geohash length: level 1 cells are
+// about 5000 km wide, level 6 ones about 1 km, level 12 ones a few
+// centimetres. Each cell has 32 children at the next level.
+type Cell string
+
+// Cell returns the cell of the point at the level, clamped to [1, 12].
+func (location Location) Cell(level int) Cell {
+	level = int(math.Max(1, math.Min(MaxGeohashPrecision, float64(level))))
+	location.Latitude = math.Max(-90, math.Min(90, location.Latitude))
+	location.Longitude = normalizeLongitude(location.Longitude)
+	hash, _ := EncodeGeohash(location, level)
+	return Cell(hash)
+}
+
+// ParseCell parses the ID of a cell, see Cell.ID.
+func ParseCell(id string) (Cell, error) {
+	if _, err := DecodeGeohash(id); err != nil {
+		return "", err
+	}
+	return Cell(strings.ToLower(id)), nil
+}
+
+// ID returns the identifier of the cell, its geohash.
+func (c Cell) ID() string { return string(c) }
+
+// Level returns the level of the cell, 0 for the zero Cell.
+func (c Cell) Level() int { return len(c) }
+
+// Region returns the bounds of the cell.
+func (c Cell) Region() Region {
+	region, _ := DecodeGeohash(string(c))
+	return region
+}
+
+// Center returns the center of the cell.
+func (c Cell) Center() Location {
+	return c.Region().Center()
+}
+
+// Contains reports whether the point is in the cell.
+func (c Cell) Contains(location Location) bool {
+	return c != "" && location.Cell(c.Level()) == c
+}
+
+// Parent returns the cell containing c at the level above, "" for level 1
+// cells.
+func (c Cell) Parent() Cell {
+	if len(c) <= 1 {
+		return ""
+	}
+	return c[:len(c)-1]
+}
+
+// Children returns the 32 cells of c at the level below, none at the last
+// level.
+func (c Cell) Children() []Cell {
+	if c == "" || len(c) >= MaxGeohashPrecision {
+		return nil
+	}
+	children := make([]Cell, 0, len(geohashAlphabet))
+	for i := 0; i < len(geohashAlphabet); i++ {
+		children = append(children, c+Cell(geohashAlphabet[i]))
+	}
+	return children
+}
+
+// Neighbors returns the cells around c at its level, fewer than 8 next to
+// the poles.
+func (c Cell) Neighbors() []Cell {
+	hashes, err := GeohashNeighbors(string(c))
+	if err != nil {
+		return nil
+	}
+	neighbors := make([]Cell, 0, len(hashes))
+	for _, hash := range hashes {
+		if hash != "" {
+			neighbors = append(neighbors, Cell(hash))
+		}
+	}
+	return neighbors
+}
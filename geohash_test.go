@@ -0,0 +1,130 @@
+package am_test
+
+import (
+	"testing"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeGeohash(t *testing.T) {
+	hash, err := am.EncodeGeohash(am.Location{Latitude: 42.605, Longitude: -5.603}, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "ezs42", hash)
+
+	hash, err = am.EncodeGeohash(am.Location{Latitude: 57.64911, Longitude: 10.40744}, 11)
+	assert.NoError(t, err)
+	assert.Equal(t, "u4pruydqqvj", hash)
+
+	_, err = am.EncodeGeohash(am.Location{}, 0)
+	assert.Error(t, err)
+	_, err = am.EncodeGeohash(am.Location{}, 13)
+	assert.Error(t, err)
+	_, err = am.EncodeGeohash(am.Location{Latitude: 91}, 5)
+	assert.Error(t, err)
+}
+
+func TestDecodeGeohash(t *testing.T) {
+	cell, err := am.DecodeGeohash("EZS42")
+	assert.NoError(t, err)
+	assert.Equal(t, am.Region{NorthLatitude: 42.626953125, SouthLatitude: 42.5830078125, WestLongitude: -5.625, EastLongitude: -5.5810546875}, cell)
+	assert.True(t, cell.Contains(am.Location{Latitude: 42.605, Longitude: -5.603}))
+
+	for _, hash := range []string{"", "ezs42a", "u4pruydqqvjxx"} {
+		_, err := am.DecodeGeohash(hash)
+		assert.ErrorIs(t, err, am.ErrInvalidGeohash, hash)
+	}
+}
+
+func TestGeohashNeighbors(t *testing.T) {
+	neighbors, err := am.GeohashNeighbors("ezs42")
+	assert.NoError(t, err)
+	assert.Equal(t, [8]string{"ezs48", "ezs49", "ezs43", "ezs41", "ezs40", "ezefp", "ezefr", "ezefx"}, neighbors)
+
+	// Across the antimeridian, east of the easternmost cells are the
+	// westernmost ones.
+	neighbors, err = am.GeohashNeighbors("xb")
+	assert.NoError(t, err)
+	assert.Equal(t, "8", neighbors[2][:1])
+
+	// Nothing north of the north pole.
+	neighbors, err = am.GeohashNeighbors("zzz")
+	assert.NoError(t, err)
+	assert.Equal(t, "", neighbors[0])
+	assert.Equal(t, "", neighbors[1])
+	assert.Equal(t, "", neighbors[7])
+	assert.Equal(t, "bpb", neighbors[2])
+
+	_, err = am.GeohashNeighbors("a")
+	assert.Error(t, err)
+}
+
+func TestGeohashCovering(t *testing.T) {
+	region := am.Region{NorthLatitude: 42.62, SouthLatitude: 42.59, WestLongitude: -5.62, EastLongitude: -5.59}
+	hashes, err := am.GeohashCovering(region, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ezs42"}, hashes)
+
+	hashes, err = am.GeohashCovering(region, 6)
+	assert.NoError(t, err)
+	assert.Greater(t, len(hashes), 1)
+	for _, loc := range []am.Location{
+		{Latitude: 42.59, Longitude: -5.62},
+		{Latitude: 42.62, Longitude: -5.59},
+		{Latitude: 42.605, Longitude: -5.605},
+	} {
+		hash, _ := am.EncodeGeohash(loc, 6)
+		assert.Contains(t, hashes, hash)
+	}
+
+	pacific := am.Region{NorthLatitude: 1, SouthLatitude: -1, WestLongitude: 179, EastLongitude: -179}
+	hashes, err = am.GeohashCovering(pacific, 2)
+	assert.NoError(t, err)
+	for _, loc := range []am.Location{{Latitude: 0, Longitude: 179.5}, {Latitude: 0, Longitude: -179.5}} {
+		hash, _ := am.EncodeGeohash(loc, 2)
+		assert.Contains(t, hashes, hash)
+	}
+	hash, _ := am.EncodeGeohash(am.Location{Latitude: 0, Longitude: 0}, 2)
+	assert.NotContains(t, hashes, hash)
+
+	world := am.Region{NorthLatitude: 90, SouthLatitude: -90, WestLongitude: -180, EastLongitude: 180}
+	hashes, err = am.GeohashCovering(world, 1)
+	assert.NoError(t, err)
+	assert.Len(t, hashes, 32)
+
+	_, err = am.GeohashCovering(world, 5)
+	assert.Error(t, err)
+}
+
+func TestLocation_Cell(t *testing.T) {
+	loc := am.Location{Latitude: 42.605, Longitude: -5.603}
+	cell := loc.Cell(5)
+	assert.Equal(t, am.Cell("ezs42"), cell)
+	assert.Equal(t, "ezs42", cell.ID())
+	assert.Equal(t, 5, cell.Level())
+	assert.True(t, cell.Contains(loc))
+	assert.False(t, cell.Contains(am.Location{}))
+	assert.True(t, cell.Region().Contains(cell.Center()))
+	assert.Equal(t, am.Cell("ezs4"), cell.Parent())
+	assert.Equal(t, am.Cell(""), am.Cell("e").Parent())
+	assert.Len(t, cell.Children(), 32)
+	assert.Contains(t, cell.Children(), loc.Cell(6))
+	assert.Nil(t, loc.Cell(12).Children())
+	assert.Len(t, cell.Neighbors(), 8)
+	assert.Len(t, am.Cell("zzz").Neighbors(), 5)
+
+	assert.Equal(t, 1, loc.Cell(0).Level())
+	assert.Equal(t, 12, loc.Cell(20).Level())
+
+	parsed, err := am.ParseCell("EZS42")
+	assert.NoError(t, err)
+	assert.Equal(t, cell, parsed)
+	_, err = am.ParseCell("")
+	assert.Error(t, err)
+
+	// Cells are map keys.
+	buckets := map[am.Cell]int{}
+	buckets[loc.Cell(5)]++
+	buckets[am.Location{Latitude: 42.6, Longitude: -5.6}.Cell(5)]++
+	assert.Equal(t, map[am.Cell]int{"ezs42": 2}, buckets)
+}
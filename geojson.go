@@ -0,0 +1,257 @@
+package am
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GeoJSONFeatureCollection is a GeoJSON FeatureCollection, see RFC 7946.
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	BBox     []float64        `json:"bbox,omitempty"`
+	Features []GeoJSONFeature `json:"features"`
+}
+
+// GeoJSONFeature is a GeoJSON Feature.
+type GeoJSONFeature struct {
+	Type       string          `json:"type"`
+	BBox       []float64       `json:"bbox,omitempty"`
+	Geometry   GeoJSONGeometry `json:"geometry"`
+	Properties map[string]any  `json:"properties"`
+}
+
+// GeoJSONGeometry is a GeoJSON Point or LineString geometry, the only kinds
+// this package produces.
+type GeoJSONGeometry struct {
+	Type string `json:"type"`
+
+	// [longitude, latitude] for Point, an array of them for LineString.
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+func newGeoJSONFeatureCollection() *GeoJSONFeatureCollection {
+	return &GeoJSONFeatureCollection{Type: "FeatureCollection", Features: []GeoJSONFeature{}}
+}
+
+func geoJSONPosition(loc Location) []float64 {
+	return []float64{loc.Longitude, loc.Latitude}
+}
+
+func newGeoJSONPoint(loc Location) GeoJSONGeometry {
+	coordinates, _ := json.Marshal(geoJSONPosition(loc))
+	return GeoJSONGeometry{Type: "Point", Coordinates: coordinates}
+}
+
+func newGeoJSONLineString(locs []Location) GeoJSONGeometry {
+	positions := make([][]float64, 0, len(locs))
+	for _, loc := range locs {
+		positions = append(positions, geoJSONPosition(loc))
+	}
+	coordinates, _ := json.Marshal(positions)
+	return GeoJSONGeometry{Type: "LineString", Coordinates: coordinates}
+}
+
+// newGeoJSONPath returns a LineString, or a Point for a single location since
+// a LineString needs at least two positions, RFC 7946 section 3.1.4.
+func newGeoJSONPath(locs []Location) GeoJSONGeometry {
+	if len(locs) == 1 {
+		return newGeoJSONPoint(locs[0])
+	}
+	return newGeoJSONLineString(locs)
+}
+
+// geoJSONBBox returns nil for the zero region, which means the response has
+// no region.
+func geoJSONBBox(region Region) []float64 {
+	if region == (Region{}) {
+		return nil
+	}
+	return []float64{region.WestLongitude, region.SouthLatitude, region.EastLongitude, region.NorthLatitude}
+}
+
+// Locations returns the points of a Point or LineString geometry.
+func (g GeoJSONGeometry) Locations() ([]Location, error) {
+	toLocation := func(position []float64) (Location, error) {
+		if len(position) < 2 {
+			return Location{}, fmt.Errorf("am: invalid GeoJSON position %v", position)
+		}
+		return Location{Latitude: position[1], Longitude: position[0]}, nil
+	}
+	switch g.Type {
+	case "Point":
+		var position []float64
+		if err := json.Unmarshal(g.Coordinates, &position); err != nil {
+			return nil, err
+		}
+		loc, err := toLocation(position)
+		if err != nil {
+			return nil, err
+		}
+		return []Location{loc}, nil
+	case "LineString":
+		var positions [][]float64
+		if err := json.Unmarshal(g.Coordinates, &positions); err != nil {
+			return nil, err
+		}
+		locs := make([]Location, 0, len(positions))
+		for _, position := range positions {
+			loc, err := toLocation(position)
+			if err != nil {
+				return nil, err
+			}
+			locs = append(locs, loc)
+		}
+		return locs, nil
+	}
+	return nil, fmt.Errorf("am: unsupported GeoJSON geometry %q", g.Type)
+}
+
+type geoJSONOptions struct {
+	steps bool
+}
+
+// GeoJSONOption configures DirectionsResponse.ToGeoJSON.
+type GeoJSONOption func(*geoJSONOptions)
+
+// Adds a LineString Feature for every step of every route, with the step
+// instructions as properties. Off by default.
+func WithGeoJSONSteps() GeoJSONOption {
+	return func(o *geoJSONOptions) {
+		o.steps = true
+	}
+}
+
+func placeGeoJSONFeature(place Place) GeoJSONFeature {
+	properties := map[string]any{
+		"name":                  place.Name,
+		"formattedAddressLines": place.FormattedAddressLines,
+		"country":               place.Country,
+		"countryCode":           place.CountryCode,
+		"structuredAddress":     place.StructuredAddress,
+	}
+	if place.ID != "" {
+		properties["id"] = place.ID
+	}
+	if place.PoiCategory != "" {
+		properties["poiCategory"] = place.PoiCategory
+	}
+	if place.Telephone != "" {
+		properties["telephone"] = place.Telephone
+	}
+	if len(place.URLs) > 0 {
+		properties["urls"] = place.URLs
+	}
+	return GeoJSONFeature{
+		Type:       "Feature",
+		BBox:       geoJSONBBox(place.DisplayMapRegion),
+		Geometry:   newGeoJSONPoint(place.Coordinate),
+		Properties: properties,
+	}
+}
+
+func placesToGeoJSON(places []Place) *GeoJSONFeatureCollection {
+	fc := newGeoJSONFeatureCollection()
+	for _, place := range places {
+		fc.Features = append(fc.Features, placeGeoJSONFeature(place))
+	}
+	return fc
+}
+
+// ToGeoJSON returns a Point Feature for every place, with the address as
+// properties and DisplayMapRegion as bbox.
+func (resp *PlaceResults) ToGeoJSON() *GeoJSONFeatureCollection {
+	return placesToGeoJSON(resp.Results)
+}
+
+// ToGeoJSON returns a Point Feature for every place, with the address as
+// properties and DisplayMapRegion as bbox. The bbox of the collection is the
+// DisplayMapRegion of the response.
+func (resp *SearchResponse) ToGeoJSON() *GeoJSONFeatureCollection {
+	fc := placesToGeoJSON(resp.Results)
+	fc.BBox = geoJSONBBox(resp.DisplayMapRegion)
+	return fc
+}
+
+// ToGeoJSON returns a Point Feature for every destination, with the distance
+// and travel times as properties.
+func (resp *EtaResponse) ToGeoJSON() *GeoJSONFeatureCollection {
+	fc := newGeoJSONFeatureCollection()
+	for _, eta := range resp.Etas {
+		fc.Features = append(fc.Features, GeoJSONFeature{
+			Type:     "Feature",
+			Geometry: newGeoJSONPoint(eta.Destination),
+			Properties: map[string]any{
+				"distanceMeters":            eta.DistanceMeters,
+				"expectedTravelTimeSeconds": eta.ExpectedTravelTimeSeconds,
+				"staticTravelTimeSeconds":   eta.StaticTravelTimeSeconds,
+				"transportType":             eta.TransportType,
+			},
+		})
+	}
+	return fc
+}
+
+// ToGeoJSON returns a LineString Feature for every route, with the distance,
+// duration and tolls as properties. Features of steps follow their route
+// when WithGeoJSONSteps is set.
+//
+// Routes and steps of a single point are Point Features, steps without
+// points are left out.
+//
+// Features have a "kind" property, "route" or "step", to tell them apart.
+func (resp *DirectionsResponse) ToGeoJSON(opts ...GeoJSONOption) (*GeoJSONFeatureCollection, error) {
+	options := &geoJSONOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	fc := newGeoJSONFeatureCollection()
+	for i := range resp.Routes {
+		route := resp.Route(i)
+		polyline, err := route.Polyline()
+		if err != nil {
+			return nil, err
+		}
+		fc.Features = append(fc.Features, GeoJSONFeature{
+			Type:     "Feature",
+			Geometry: newGeoJSONPath(polyline),
+			Properties: map[string]any{
+				"kind":            "route",
+				"routeIndex":      i,
+				"name":            route.Name,
+				"distanceMeters":  route.DistanceMeters,
+				"durationSeconds": route.DurationSeconds,
+				"hasTolls":        route.HasTolls,
+				"transportType":   route.TransportType,
+			},
+		})
+		if !options.steps {
+			continue
+		}
+		steps, err := route.Steps()
+		if err != nil {
+			return nil, err
+		}
+		for _, step := range steps {
+			path, err := step.Path()
+			if err != nil {
+				return nil, err
+			}
+			if len(path) == 0 {
+				continue
+			}
+			fc.Features = append(fc.Features, GeoJSONFeature{
+				Type:     "Feature",
+				Geometry: newGeoJSONPath(path),
+				Properties: map[string]any{
+					"kind":            "step",
+					"routeIndex":      i,
+					"stepIndex":       step.Index,
+					"instructions":    step.Instructions,
+					"distanceMeters":  step.DistanceMeters,
+					"durationSeconds": step.DurationSeconds,
+				},
+			})
+		}
+	}
+	return fc, nil
+}
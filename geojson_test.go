@@ -0,0 +1,159 @@
+package am_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+func roundTripGeoJSON(t *testing.T, fc *am.GeoJSONFeatureCollection) *am.GeoJSONFeatureCollection {
+	t.Helper()
+	data, err := json.Marshal(fc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &am.GeoJSONFeatureCollection{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "FeatureCollection", decoded.Type)
+	return decoded
+}
+
+func TestDirectionsResponse_ToGeoJSON(t *testing.T) {
+	resp := loadDirectionsResponse(t)
+
+	fc, err := resp.ToGeoJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := roundTripGeoJSON(t, fc)
+	assert.Len(t, decoded.Features, len(resp.Routes))
+	for i, feature := range decoded.Features {
+		route := resp.Route(i)
+		polyline, _ := route.Polyline()
+		locs, err := feature.Geometry.Locations()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "LineString", feature.Geometry.Type)
+		assert.Equal(t, polyline, locs)
+		assert.Equal(t, "route", feature.Properties["kind"])
+		assert.Equal(t, float64(route.DistanceMeters), feature.Properties["distanceMeters"])
+		assert.Equal(t, float64(route.DurationSeconds), feature.Properties["durationSeconds"])
+		assert.Equal(t, route.HasTolls, feature.Properties["hasTolls"])
+	}
+
+	fc, err = resp.ToGeoJSON(am.WithGeoJSONSteps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded = roundTripGeoJSON(t, fc)
+	assert.Len(t, decoded.Features, len(resp.Routes)+len(resp.Steps))
+	step := decoded.Features[1]
+	assert.Equal(t, "step", step.Properties["kind"])
+	assert.Equal(t, resp.Steps[0].Instructions, step.Properties["instructions"])
+	locs, err := step.Geometry.Locations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, resp.StepPaths[0], locs)
+
+	resp.Steps[0].StepPathIndex = -1
+	_, err = resp.ToGeoJSON(am.WithGeoJSONSteps())
+	assert.ErrorIs(t, err, am.ErrInvalidDirections)
+
+	// A LineString needs 2 positions: a step of 1 point is a Point, one
+	// without points is left out.
+	point := am.Location{Latitude: 1, Longitude: 2}
+	resp = &am.DirectionsResponse{
+		Routes:    []am.DirectionsResponseRoute{{StepIndexes: []int64{0, 1, 2}}},
+		Steps:     []am.DirectionsResponseStep{{StepPathIndex: 0}, {StepPathIndex: 1}, {StepPathIndex: 2}},
+		StepPaths: [][]am.Location{{point}, {}, {point, {Latitude: 3, Longitude: 4}}},
+	}
+	fc, err = resp.ToGeoJSON(am.WithGeoJSONSteps())
+	assert.NoError(t, err)
+	decoded = roundTripGeoJSON(t, fc)
+	assert.Len(t, decoded.Features, 3)
+	assert.Equal(t, "LineString", decoded.Features[0].Geometry.Type)
+	assert.Equal(t, "Point", decoded.Features[1].Geometry.Type)
+	assert.Equal(t, float64(0), decoded.Features[1].Properties["stepIndex"])
+	locs, err = decoded.Features[1].Geometry.Locations()
+	assert.NoError(t, err)
+	assert.Equal(t, []am.Location{point}, locs)
+	assert.Equal(t, "LineString", decoded.Features[2].Geometry.Type)
+	assert.Equal(t, float64(2), decoded.Features[2].Properties["stepIndex"])
+
+	resp.Routes[0].StepIndexes = []int64{0}
+	fc, err = resp.ToGeoJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, "Point", fc.Features[0].Geometry.Type)
+}
+
+func TestSearchResponse_ToGeoJSON(t *testing.T) {
+	resp := &am.SearchResponse{}
+	if err := json.Unmarshal(expectSearchResponse1, resp); err != nil {
+		t.Fatal(err)
+	}
+	decoded := roundTripGeoJSON(t, resp.ToGeoJSON())
+	region := resp.DisplayMapRegion
+	assert.Equal(t, []float64{region.WestLongitude, region.SouthLatitude, region.EastLongitude, region.NorthLatitude}, decoded.BBox)
+	assert.Len(t, decoded.Features, len(resp.Results))
+	for i, feature := range decoded.Features {
+		place := resp.Results[i]
+		locs, err := feature.Geometry.Locations()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "Point", feature.Geometry.Type)
+		assert.Equal(t, []am.Location{place.Coordinate}, locs)
+		assert.Equal(t, place.Name, feature.Properties["name"])
+		assert.Equal(t, place.CountryCode, feature.Properties["countryCode"])
+		if place.DisplayMapRegion == (am.Region{}) {
+			assert.Nil(t, feature.BBox)
+		} else {
+			assert.Len(t, feature.BBox, 4)
+		}
+	}
+}
+
+func TestPlaceResults_ToGeoJSON(t *testing.T) {
+	resp := &am.PlaceResults{}
+	if err := json.Unmarshal(expectPlaceResultsResponse1, resp); err != nil {
+		t.Fatal(err)
+	}
+	decoded := roundTripGeoJSON(t, resp.ToGeoJSON())
+	assert.Nil(t, decoded.BBox)
+	assert.Len(t, decoded.Features, len(resp.Results))
+	locs, err := decoded.Features[0].Geometry.Locations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []am.Location{resp.Results[0].Coordinate}, locs)
+}
+
+func TestEtaResponse_ToGeoJSON(t *testing.T) {
+	resp := &am.EtaResponse{}
+	if err := json.Unmarshal(expectEtaResponse1, resp); err != nil {
+		t.Fatal(err)
+	}
+	decoded := roundTripGeoJSON(t, resp.ToGeoJSON())
+	assert.Len(t, decoded.Features, len(resp.Etas))
+	for i, feature := range decoded.Features {
+		locs, err := feature.Geometry.Locations()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, []am.Location{resp.Etas[i].Destination}, locs)
+		assert.Equal(t, string(resp.Etas[i].TransportType), feature.Properties["transportType"])
+	}
+}
+
+func TestGeoJSONGeometry_Locations_Invalid(t *testing.T) {
+	_, err := am.GeoJSONGeometry{Type: "Polygon", Coordinates: json.RawMessage(`[]`)}.Locations()
+	assert.Error(t, err)
+	_, err = am.GeoJSONGeometry{Type: "Point", Coordinates: json.RawMessage(`[1]`)}.Locations()
+	assert.Error(t, err)
+}
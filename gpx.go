@@ -0,0 +1,116 @@
+package am
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+const (
+	gpxNamespace = "http://www.topografix.com/GPX/1/1"
+	xmlCreator   = "github.com/ringsaturn/am"
+)
+
+func formatCoordinate(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func xmlName(local string) xml.Name {
+	return xml.Name{Local: local}
+}
+
+func encodeXMLText(enc *xml.Encoder, name string, text string) error {
+	return enc.EncodeElement(text, xml.StartElement{Name: xmlName(name)})
+}
+
+func encodeGPXPoint(enc *xml.Encoder, name string, loc Location, children func() error) error {
+	start := xml.StartElement{
+		Name: xmlName(name),
+		Attr: []xml.Attr{
+			{Name: xmlName("lat"), Value: formatCoordinate(loc.Latitude)},
+			{Name: xmlName("lon"), Value: formatCoordinate(loc.Longitude)},
+		},
+	}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	if children != nil {
+		if err := children(); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+// WriteGPX writes the route as a GPX 1.1 document: a track with the points
+// of the route, and a waypoint at the start of every step with the step
+// instructions as name.
+func (r *DirectionsRoute) WriteGPX(w io.Writer) error {
+	steps, err := r.Steps()
+	if err != nil {
+		return err
+	}
+	polyline, err := r.Polyline()
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	gpx := xml.StartElement{
+		Name: xmlName("gpx"),
+		Attr: []xml.Attr{
+			{Name: xmlName("xmlns"), Value: gpxNamespace},
+			{Name: xmlName("version"), Value: "1.1"},
+			{Name: xmlName("creator"), Value: xmlCreator},
+		},
+	}
+	if err := enc.EncodeToken(gpx); err != nil {
+		return err
+	}
+
+	// GPX wants the waypoints before the tracks.
+	for _, step := range steps {
+		path, err := step.Path()
+		if err != nil {
+			return err
+		}
+		if len(path) == 0 || step.Instructions == "" {
+			continue
+		}
+		err = encodeGPXPoint(enc, "wpt", path[0], func() error {
+			return encodeXMLText(enc, "name", step.Instructions)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	trk := xml.StartElement{Name: xmlName("trk")}
+	trkseg := xml.StartElement{Name: xmlName("trkseg")}
+	if err := enc.EncodeToken(trk); err != nil {
+		return err
+	}
+	if r.Name != "" {
+		if err := encodeXMLText(enc, "name", r.Name); err != nil {
+			return err
+		}
+	}
+	if err := enc.EncodeToken(trkseg); err != nil {
+		return err
+	}
+	for _, loc := range polyline {
+		if err := encodeGPXPoint(enc, "trkpt", loc, nil); err != nil {
+			return err
+		}
+	}
+	for _, end := range []xml.EndElement{trkseg.End(), trk.End(), gpx.End()} {
+		if err := enc.EncodeToken(end); err != nil {
+			return err
+		}
+	}
+	return enc.Flush()
+}
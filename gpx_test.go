@@ -0,0 +1,68 @@
+package am_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Name string  `xml:"name"`
+}
+
+type gpxDocument struct {
+	XMLName   xml.Name   `xml:"http://www.topografix.com/GPX/1/1 gpx"`
+	Version   string     `xml:"version,attr"`
+	Waypoints []gpxPoint `xml:"wpt"`
+	Track     struct {
+		Name   string     `xml:"name"`
+		Points []gpxPoint `xml:"trkseg>trkpt"`
+	} `xml:"trk"`
+}
+
+func TestDirectionsRoute_WriteGPX(t *testing.T) {
+	resp := loadDirectionsResponse(t)
+	route := resp.Route(0)
+
+	buf := &bytes.Buffer{}
+	if err := route.WriteGPX(buf); err != nil {
+		t.Fatal(err)
+	}
+	doc := &gpxDocument{}
+	if err := xml.Unmarshal(buf.Bytes(), doc); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "1.1", doc.Version)
+	assert.Equal(t, route.Name, doc.Track.Name)
+
+	polyline, _ := route.Polyline()
+	assert.Len(t, doc.Track.Points, len(polyline))
+	for i, point := range doc.Track.Points {
+		assert.Equal(t, polyline[i], am.Location{Latitude: point.Lat, Longitude: point.Lon})
+	}
+
+	steps, _ := route.Steps()
+	var want []gpxPoint
+	for _, step := range steps {
+		path, _ := step.Path()
+		if len(path) == 0 || step.Instructions == "" {
+			continue
+		}
+		want = append(want, gpxPoint{Lat: path[0].Latitude, Lon: path[0].Longitude, Name: step.Instructions})
+	}
+	assert.NotEmpty(t, want)
+	assert.Equal(t, want, doc.Waypoints)
+}
+
+func TestDirectionsRoute_WriteGPX_Invalid(t *testing.T) {
+	resp := loadDirectionsResponse(t)
+	resp.Routes[0].StepIndexes = append(resp.Routes[0].StepIndexes, 100)
+	buf := &bytes.Buffer{}
+	assert.ErrorIs(t, resp.Route(0).WriteGPX(buf), am.ErrInvalidDirections)
+	assert.Zero(t, buf.Len())
+}
@@ -0,0 +1,115 @@
+// Command genpoicategory generates the PoiCategory constants of package am
+// from the checked-in catalog file.
+//
+// Usage:
+//
+//	go run ./internal/cmd/genpoicategory -in poicategory.txt -out poicategory_gen.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+)
+
+type category struct {
+	Name        string
+	Description string
+}
+
+var validName = regexp.MustCompile(`^[A-Z][A-Za-z]*$`)
+
+func parse(r io.Reader) (string, []category, error) {
+	var (
+		version    string
+		categories []category
+		seen       = map[string]bool{}
+	)
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, desc, ok := strings.Cut(line, "\t")
+		if !ok {
+			return "", nil, fmt.Errorf("line %d: expect name and description separated by tab", lineNo)
+		}
+		name, desc = strings.TrimSpace(name), strings.TrimSpace(desc)
+		if name == "version" {
+			version = desc
+			continue
+		}
+		if !validName.MatchString(name) {
+			return "", nil, fmt.Errorf("line %d: invalid category name %q", lineNo, name)
+		}
+		if seen[strings.ToLower(name)] {
+			return "", nil, fmt.Errorf("line %d: duplicated category %q", lineNo, name)
+		}
+		seen[strings.ToLower(name)] = true
+		categories = append(categories, category{Name: name, Description: desc})
+	}
+	if err := scanner.Err(); err != nil {
+		return "", nil, err
+	}
+	if version == "" {
+		return "", nil, errors.New("missing version line")
+	}
+	if len(categories) == 0 {
+		return "", nil, errors.New("no category found")
+	}
+	return version, categories, nil
+}
+
+func generate(r io.Reader, source string) ([]byte, error) {
+	version, categories, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "// Code generated by genpoicategory from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(buf, "package am\n\n")
+	fmt.Fprintf(buf, "// PoiCategoryCatalogVersion is the version of the PoiCategory catalog\n")
+	fmt.Fprintf(buf, "// this package was generated from.\n")
+	fmt.Fprintf(buf, "const PoiCategoryCatalogVersion = %q\n\n", version)
+	fmt.Fprintf(buf, "const (\n")
+	for _, c := range categories {
+		fmt.Fprintf(buf, "\t%s PoiCategory = %q // %s\n", c.Name, c.Name, c.Description)
+	}
+	fmt.Fprintf(buf, ")\n\n")
+	fmt.Fprintf(buf, "var allPoiCategories = []PoiCategory{\n")
+	for _, c := range categories {
+		fmt.Fprintf(buf, "\t%s,\n", c.Name)
+	}
+	fmt.Fprintf(buf, "}\n")
+	return format.Source(buf.Bytes())
+}
+
+func main() {
+	in := flag.String("in", "poicategory.txt", "catalog file")
+	out := flag.String("out", "poicategory_gen.go", "output Go file")
+	flag.Parse()
+
+	f, err := os.Open(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	src, err := generate(f, *in)
+	if err != nil {
+		log.Fatalf("%s: %v", *in, err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Fails when poicategory_gen.go was edited by hand or poicategory.txt was
+// changed without running `go generate`.
+func TestGeneratedInSync(t *testing.T) {
+	f, err := os.Open("../../../poicategory.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	got, err := generate(f, "poicategory.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile("../../../poicategory_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("poicategory_gen.go is out of date, please run `make generate`")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "no version", input: "Airport\tAn airport.\n"},
+		{name: "no tab", input: "version\t1\nAirport An airport.\n"},
+		{name: "invalid name", input: "version\t1\nair port\tAn airport.\n"},
+		{name: "duplicated", input: "version\t1\nAirport\tAn airport.\nairport\tAn airport.\n"},
+		{name: "empty", input: "version\t1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := parse(strings.NewReader(tt.input)); err == nil {
+				t.Errorf("parse() expect error for %q", tt.input)
+			}
+		})
+	}
+}
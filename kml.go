@@ -0,0 +1,164 @@
+package am
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const kmlNamespace = "http://www.opengis.net/kml/2.2"
+
+// KMLEncoder writes places and routes as the placemarks of a KML document.
+// Placemarks are written as they are encoded, call Close to end the
+// document.
+//
+//	enc := am.NewKMLEncoder(w)
+//	for _, place := range resp.Results {
+//		if err := enc.EncodePlace(place); err != nil {
+//			return err
+//		}
+//	}
+//	return enc.Close()
+type KMLEncoder struct {
+	w       io.Writer
+	enc     *xml.Encoder
+	started bool
+	err     error
+}
+
+// NewKMLEncoder returns an encoder writing to w.
+func NewKMLEncoder(w io.Writer) *KMLEncoder {
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return &KMLEncoder{w: w, enc: enc}
+}
+
+func (e *KMLEncoder) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	if _, err := io.WriteString(e.w, xml.Header); err != nil {
+		return err
+	}
+	kml := xml.StartElement{
+		Name: xmlName("kml"),
+		Attr: []xml.Attr{{Name: xmlName("xmlns"), Value: kmlNamespace}},
+	}
+	if err := e.enc.EncodeToken(kml); err != nil {
+		return err
+	}
+	return e.enc.EncodeToken(xml.StartElement{Name: xmlName("Document")})
+}
+
+// placemark writes a Placemark, geometry writes its geometry element.
+func (e *KMLEncoder) placemark(name, description string, geometry func() error) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.err = func() error {
+		if err := e.start(); err != nil {
+			return err
+		}
+		placemark := xml.StartElement{Name: xmlName("Placemark")}
+		if err := e.enc.EncodeToken(placemark); err != nil {
+			return err
+		}
+		if name != "" {
+			if err := encodeXMLText(e.enc, "name", name); err != nil {
+				return err
+			}
+		}
+		if description != "" {
+			if err := encodeXMLText(e.enc, "description", description); err != nil {
+				return err
+			}
+		}
+		if err := geometry(); err != nil {
+			return err
+		}
+		if err := e.enc.EncodeToken(placemark.End()); err != nil {
+			return err
+		}
+		return e.enc.Flush()
+	}()
+	return e.err
+}
+
+func kmlCoordinate(loc Location) string {
+	return formatCoordinate(loc.Longitude) + "," + formatCoordinate(loc.Latitude)
+}
+
+// EncodePlace writes the place as a Point placemark, with the formatted
+// address as description.
+func (e *KMLEncoder) EncodePlace(place Place) error {
+	return e.placemark(place.Name, strings.Join(place.FormattedAddressLines, "\n"), func() error {
+		point := xml.StartElement{Name: xmlName("Point")}
+		if err := e.enc.EncodeToken(point); err != nil {
+			return err
+		}
+		if err := encodeXMLText(e.enc, "coordinates", kmlCoordinate(place.Coordinate)); err != nil {
+			return err
+		}
+		return e.enc.EncodeToken(point.End())
+	})
+}
+
+// EncodeRoute writes the route as a LineString placemark, with the distance
+// and duration as description.
+func (e *KMLEncoder) EncodeRoute(route *DirectionsRoute) error {
+	if e.err != nil {
+		return e.err
+	}
+	polyline, err := route.Polyline()
+	if err != nil {
+		return err
+	}
+	description := fmt.Sprintf("%d m, %d s", route.DistanceMeters, route.DurationSeconds)
+	return e.placemark(route.Name, description, func() error {
+		lineString := xml.StartElement{Name: xmlName("LineString")}
+		coordinates := xml.StartElement{Name: xmlName("coordinates")}
+		if err := e.enc.EncodeToken(lineString); err != nil {
+			return err
+		}
+		if err := encodeXMLText(e.enc, "tessellate", "1"); err != nil {
+			return err
+		}
+		if err := e.enc.EncodeToken(coordinates); err != nil {
+			return err
+		}
+		for i, loc := range polyline {
+			text := kmlCoordinate(loc)
+			if i > 0 {
+				text = " " + text
+			}
+			if err := e.enc.EncodeToken(xml.CharData(text)); err != nil {
+				return err
+			}
+		}
+		if err := e.enc.EncodeToken(coordinates.End()); err != nil {
+			return err
+		}
+		return e.enc.EncodeToken(lineString.End())
+	})
+}
+
+// Close ends the document. An empty document is written if nothing was
+// encoded.
+func (e *KMLEncoder) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+	if err := e.start(); err != nil {
+		return err
+	}
+	if err := e.enc.EncodeToken(xml.EndElement{Name: xmlName("Document")}); err != nil {
+		return err
+	}
+	if err := e.enc.EncodeToken(xml.EndElement{Name: xmlName("kml")}); err != nil {
+		return err
+	}
+	e.err = fmt.Errorf("am: KML encoder is closed")
+	return e.enc.Flush()
+}
@@ -0,0 +1,100 @@
+package am_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"testing"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+type kmlPlacemark struct {
+	Name        string `xml:"name"`
+	Description string `xml:"description"`
+	Point       *struct {
+		Coordinates string `xml:"coordinates"`
+	} `xml:"Point"`
+	LineString *struct {
+		Coordinates string `xml:"coordinates"`
+	} `xml:"LineString"`
+}
+
+type kmlDocument struct {
+	XMLName    xml.Name       `xml:"http://www.opengis.net/kml/2.2 kml"`
+	Placemarks []kmlPlacemark `xml:"Document>Placemark"`
+}
+
+func parseKMLCoordinates(t *testing.T, text string) []am.Location {
+	t.Helper()
+	var locs []am.Location
+	for _, tuple := range strings.Fields(text) {
+		parts := strings.Split(tuple, ",")
+		if len(parts) < 2 {
+			t.Fatalf("invalid coordinates %q", tuple)
+		}
+		lon, err := strconv.ParseFloat(parts[0], 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lat, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		locs = append(locs, am.Location{Latitude: lat, Longitude: lon})
+	}
+	return locs
+}
+
+func TestKMLEncoder(t *testing.T) {
+	places := &am.SearchResponse{}
+	if err := json.Unmarshal(expectSearchResponse1, places); err != nil {
+		t.Fatal(err)
+	}
+	directions := loadDirectionsResponse(t)
+
+	buf := &bytes.Buffer{}
+	enc := am.NewKMLEncoder(buf)
+	for _, place := range places.Results {
+		assert.NoError(t, enc.EncodePlace(place))
+	}
+	for i := range directions.Routes {
+		assert.NoError(t, enc.EncodeRoute(directions.Route(i)))
+	}
+	assert.NoError(t, enc.Close())
+	assert.Error(t, enc.EncodePlace(places.Results[0]))
+
+	doc := &kmlDocument{}
+	if err := xml.Unmarshal(buf.Bytes(), doc); err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, doc.Placemarks, len(places.Results)+len(directions.Routes))
+	for i, place := range places.Results {
+		placemark := doc.Placemarks[i]
+		assert.Equal(t, place.Name, placemark.Name)
+		assert.Equal(t, strings.Join(place.FormattedAddressLines, "\n"), placemark.Description)
+		if assert.NotNil(t, placemark.Point) {
+			assert.Equal(t, []am.Location{place.Coordinate}, parseKMLCoordinates(t, placemark.Point.Coordinates))
+		}
+	}
+	for i := range directions.Routes {
+		route := directions.Route(i)
+		placemark := doc.Placemarks[len(places.Results)+i]
+		assert.Equal(t, route.Name, placemark.Name)
+		polyline, _ := route.Polyline()
+		if assert.NotNil(t, placemark.LineString) {
+			assert.Equal(t, polyline, parseKMLCoordinates(t, placemark.LineString.Coordinates))
+		}
+	}
+}
+
+func TestKMLEncoder_Empty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, am.NewKMLEncoder(buf).Close())
+	doc := &kmlDocument{}
+	assert.NoError(t, xml.Unmarshal(buf.Bytes(), doc))
+	assert.Empty(t, doc.Placemarks)
+}
@@ -0,0 +1,431 @@
+package am
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidLocation is wrapped by the errors of ParseLocation.
+var ErrInvalidLocation = errors.New("am: invalid location")
+
+// ParseLocation parses a location written in one of these forms:
+//
+//   - decimal degrees, latitude first: "37.33,-122.03" or "37.33 -122.03"
+//   - degrees, minutes and seconds: `37°19'55"N 122°1'48"W`, "N 37° 19.9'
+//     W 122° 1.8'", or signed degrees without hemispheres
+//   - WKT, longitude first: "POINT(-122.03 37.33)"
+//   - geo URI, RFC 5870: "geo:37.33,-122.03;u=35", only in WGS-84
+//   - geohash, lowercase: "9q9hrh", the center of the cell is returned.
+//     Geohashes made only of digits and the letters n, s, e and w read like
+//     coordinates, they need the "geohash:" prefix: "geohash:s00"
+//
+// Numbers without units need a hemisphere letter to be read as degrees,
+// minutes and seconds, "12 34 56 78" is ambiguous and rejected.
+//
+// Errors wrap ErrInvalidLocation and tell what is wrong with the input.
+func ParseLocation(s string) (Location, error) {
+	text := strings.TrimSpace(s)
+	lower := strings.ToLower(text)
+
+	var (
+		location Location
+		err      error
+	)
+	switch {
+	case text == "":
+		err = errors.New("empty input")
+	case strings.HasPrefix(lower, "geo:"):
+		location, err = parseGeoURI(text[len("geo:"):])
+	case strings.HasPrefix(lower, "point"):
+		location, err = parseWKTPoint(text[len("point"):])
+	case strings.HasPrefix(lower, "geohash:"):
+		location, err = parseGeohashLocation(strings.TrimSpace(text[len("geohash:"):]))
+	default:
+		var ok bool
+		if location, ok = parseDecimalLocation(text); ok {
+			break
+		}
+		if isGeohash(text) {
+			location, err = parseGeohashLocation(text)
+			break
+		}
+		location, err = parseDMSLocation(text)
+		if err != nil && isAmbiguousGeohash(text) {
+			err = fmt.Errorf("%v; use the \"geohash:\" prefix for a geohash of digits and n, s, e, w only", err)
+		}
+	}
+	if err == nil {
+		err = checkLocationRange(location)
+	}
+	if err != nil {
+		return Location{}, fmt.Errorf("%w %q: %v", ErrInvalidLocation, s, err)
+	}
+	return location, nil
+}
+
+func checkLocationRange(location Location) error {
+	if location.Latitude < -90 || location.Latitude > 90 {
+		return fmt.Errorf("latitude %v out of [-90, 90]", location.Latitude)
+	}
+	if location.Longitude < -180 || location.Longitude > 180 {
+		return fmt.Errorf("longitude %v out of [-180, 180]", location.Longitude)
+	}
+	return nil
+}
+
+// parseCoordinate parses a finite number, strconv.ParseFloat also accepts
+// NaN and infinities.
+func parseCoordinate(s string) (float64, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	return v, nil
+}
+
+func parseDecimalLocation(s string) (Location, bool) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		parts = strings.Fields(s)
+	}
+	if len(parts) != 2 {
+		return Location{}, false
+	}
+	lat, err := parseCoordinate(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return Location{}, false
+	}
+	lon, err := parseCoordinate(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return Location{}, false
+	}
+	return Location{Latitude: lat, Longitude: lon}, true
+}
+
+func parseGeohashLocation(s string) (Location, error) {
+	cell, err := DecodeGeohash(s)
+	if err != nil {
+		return Location{}, err
+	}
+	return cell.Center(), nil
+}
+
+// isGeohash reports whether s looks like a geohash. Only lowercase ones are
+// accepted, so "37N122W" is left to the DMS parser, and they need a letter
+// other than the hemispheres, so "123" or "s" aren't mistaken for one.
+func isGeohash(s string) bool {
+	return isGeohashAlphabet(s) && strings.ContainsAny(s, "bcdfghjkmpqrtuvxyz")
+}
+
+// isAmbiguousGeohash reports whether s could be a geohash that isGeohash
+// rejects.
+func isAmbiguousGeohash(s string) bool {
+	return isGeohashAlphabet(s) && !isGeohash(s)
+}
+
+func isGeohashAlphabet(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if geohashIndex[s[i]] < 0 {
+			return false
+		}
+	}
+	return len(s) <= MaxGeohashPrecision
+}
+
+func parseGeoURI(s string) (Location, error) {
+	coordinates, params, _ := strings.Cut(s, ";")
+	for _, param := range strings.Split(params, ";") {
+		key, value, _ := strings.Cut(param, "=")
+		if strings.EqualFold(strings.TrimSpace(key), "crs") && !strings.EqualFold(strings.TrimSpace(value), "wgs84") {
+			return Location{}, fmt.Errorf("unsupported geo URI crs %q", value)
+		}
+	}
+	parts := strings.Split(coordinates, ",")
+	if len(parts) != 2 && len(parts) != 3 {
+		return Location{}, errors.New("geo URI wants latitude,longitude[,altitude]")
+	}
+	lat, err := parseCoordinate(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return Location{}, err
+	}
+	lon, err := parseCoordinate(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return Location{}, err
+	}
+	if len(parts) == 3 {
+		if _, err := parseCoordinate(strings.TrimSpace(parts[2])); err != nil {
+			return Location{}, err
+		}
+	}
+	return Location{Latitude: lat, Longitude: lon}, nil
+}
+
+func parseWKTPoint(s string) (Location, error) {
+	s = strings.TrimSpace(s)
+	// Dimensions of "POINT Z (...)" and the like.
+	for _, dims := range []string{"zm", "z", "m"} {
+		if len(s) > len(dims) && strings.EqualFold(s[:len(dims)], dims) &&
+			(s[len(dims)] == ' ' || s[len(dims)] == '(') {
+			s = strings.TrimSpace(s[len(dims):])
+			break
+		}
+	}
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return Location{}, errors.New("WKT point wants POINT(longitude latitude)")
+	}
+	fields := strings.Fields(s[1 : len(s)-1])
+	if len(fields) < 2 || len(fields) > 4 {
+		return Location{}, errors.New("WKT point wants POINT(longitude latitude)")
+	}
+	values := make([]float64, len(fields))
+	for i, field := range fields {
+		v, err := parseCoordinate(field)
+		if err != nil {
+			return Location{}, err
+		}
+		values[i] = v
+	}
+	return Location{Latitude: values[1], Longitude: values[0]}, nil
+}
+
+// Units of the parts of a DMS coordinate.
+const (
+	dmsNone = iota - 1
+	dmsDegrees
+	dmsMinutes
+	dmsSeconds
+)
+
+type dmsPart struct {
+	text     string
+	value    float64
+	unit     int
+	inferred bool // unit guessed from the previous part
+}
+
+type dmsCoordinate struct {
+	hemisphere rune // 'N', 'S', 'E', 'W' or 0
+	parts      []dmsPart
+}
+
+func dmsUnit(r rune) int {
+	switch r {
+	case '°', 'º', '˚':
+		return dmsDegrees
+	case '\'', '′', '’':
+		return dmsMinutes
+	case '"', '″', '”':
+		return dmsSeconds
+	}
+	return dmsNone
+}
+
+// parseDMSLocation parses two coordinates of degrees, minutes and seconds.
+// A coordinate ends at a separator, at its hemisphere letter, or when the
+// next number can't continue it.
+func parseDMSLocation(s string) (Location, error) {
+	var (
+		coordinates []dmsCoordinate
+		current     dmsCoordinate
+	)
+	flush := func() {
+		if current.hemisphere != 0 || len(current.parts) > 0 {
+			coordinates = append(coordinates, current)
+		}
+		current = dmsCoordinate{}
+	}
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == ',' || r == ';':
+			flush()
+			i++
+		case strings.ContainsRune("NSEWnsew", r):
+			hemisphere := unicode.ToUpper(r)
+			switch {
+			case current.hemisphere != 0:
+				flush()
+				current.hemisphere = hemisphere
+			case len(current.parts) > 0:
+				current.hemisphere = hemisphere
+				flush()
+			default:
+				current.hemisphere = hemisphere
+			}
+			i++
+		case r == '+' || r == '-' || r == '.' || unicode.IsDigit(r):
+			start := i
+			i++
+			for i < len(runes) && (runes[i] == '.' || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			part := dmsPart{text: string(runes[start:i]), unit: dmsNone}
+			v, err := parseCoordinate(part.text)
+			if err != nil {
+				return Location{}, err
+			}
+			part.value = v
+			j := i
+			for j < len(runes) && unicode.IsSpace(runes[j]) {
+				j++
+			}
+			if j < len(runes) {
+				if part.unit = dmsUnit(runes[j]); part.unit != dmsNone {
+					i = j + 1
+					// '' is a common spelling of ".
+					if part.unit == dmsMinutes && i < len(runes) && runes[i] == '\'' {
+						part.unit = dmsSeconds
+						i++
+					}
+				}
+			}
+			last := dmsNone
+			if n := len(current.parts); n > 0 {
+				last = current.parts[n-1].unit
+			}
+			part.inferred = part.unit == dmsNone
+			if part.inferred {
+				part.unit = last + 1
+			}
+			signed := r == '+' || r == '-'
+			if len(current.parts) > 0 && (part.unit <= last || part.unit > dmsSeconds || signed) {
+				flush()
+				if part.inferred {
+					part.unit = dmsDegrees
+				}
+			}
+			current.parts = append(current.parts, part)
+		case dmsUnit(r) != dmsNone:
+			return Location{}, fmt.Errorf("unit %q without a number", r)
+		default:
+			return Location{}, fmt.Errorf("unexpected %q", r)
+		}
+	}
+	flush()
+
+	if len(coordinates) != 2 {
+		return Location{}, fmt.Errorf("found %d coordinates, want latitude and longitude", len(coordinates))
+	}
+	first, second := coordinates[0], coordinates[1]
+	isLatitude := func(c dmsCoordinate) bool { return c.hemisphere == 'N' || c.hemisphere == 'S' }
+	isLongitude := func(c dmsCoordinate) bool { return c.hemisphere == 'E' || c.hemisphere == 'W' }
+	if isLongitude(first) || isLatitude(second) {
+		first, second = second, first
+	}
+	if isLongitude(first) || isLatitude(second) {
+		return Location{}, errors.New("both coordinates are on the same axis")
+	}
+	lat, err := first.value()
+	if err != nil {
+		return Location{}, err
+	}
+	lon, err := second.value()
+	if err != nil {
+		return Location{}, err
+	}
+	return Location{Latitude: lat, Longitude: lon}, nil
+}
+
+func (c dmsCoordinate) value() (float64, error) {
+	if len(c.parts) == 0 {
+		return 0, fmt.Errorf("hemisphere %c without degrees", c.hemisphere)
+	}
+	if c.parts[0].unit != dmsDegrees {
+		return 0, fmt.Errorf("%q has no degrees", c.parts[0].text)
+	}
+	if c.hemisphere == 0 && len(c.parts) > 1 {
+		texts := make([]string, 0, len(c.parts))
+		explicit := false
+		for _, part := range c.parts {
+			texts = append(texts, part.text)
+			explicit = explicit || !part.inferred
+		}
+		if !explicit {
+			return 0, fmt.Errorf("%q has neither units nor hemisphere, it is ambiguous", strings.Join(texts, " "))
+		}
+	}
+	var value float64
+	negative := false
+	for i, part := range c.parts {
+		text := part.text
+		if i == 0 {
+			negative = strings.HasPrefix(text, "-")
+		} else if strings.ContainsAny(text, "+-") {
+			return 0, fmt.Errorf("signed minutes or seconds %q", text)
+		}
+		if i < len(c.parts)-1 && strings.Contains(text, ".") {
+			return 0, fmt.Errorf("fractional %q followed by smaller units", text)
+		}
+		v := math.Abs(part.value)
+		switch part.unit {
+		case dmsMinutes:
+			if v >= 60 {
+				return 0, fmt.Errorf("minutes %q out of [0, 60)", text)
+			}
+			v /= 60
+		case dmsSeconds:
+			if v >= 60 {
+				return 0, fmt.Errorf("seconds %q out of [0, 60)", text)
+			}
+			v /= 3600
+		}
+		value += v
+	}
+	if c.hemisphere != 0 && negative {
+		return 0, fmt.Errorf("negative degrees with hemisphere %c", c.hemisphere)
+	}
+	if negative || c.hemisphere == 'S' || c.hemisphere == 'W' {
+		value = -value
+	}
+	return value, nil
+}
+
+// LocationFormat is a style of Location.Format.
+type LocationFormat int
+
+const (
+	// 37.33,-122.03, like QueryString.
+	LocationFormatDecimal LocationFormat = iota
+	// 37°19'48"N 122°1'48"W, seconds rounded to the hundredth.
+	LocationFormatDMS
+	// geo:37.33,-122.03
+	LocationFormatGeoURI
+	// POINT(-122.03 37.33)
+	LocationFormatWKT
+)
+
+// Format writes the location in the style, which ParseLocation reads back.
+// Unknown styles fall back to LocationFormatDecimal.
+func (location Location) Format(style LocationFormat) string {
+	lat := strconv.FormatFloat(location.Latitude, 'f', -1, 64)
+	lon := strconv.FormatFloat(location.Longitude, 'f', -1, 64)
+	switch style {
+	case LocationFormatDMS:
+		return formatDMS(location.Latitude, 'N', 'S') + " " + formatDMS(location.Longitude, 'E', 'W')
+	case LocationFormatGeoURI:
+		return "geo:" + lat + "," + lon
+	case LocationFormatWKT:
+		return "POINT(" + lon + " " + lat + ")"
+	}
+	return lat + "," + lon
+}
+
+func formatDMS(v float64, positive, negative byte) string {
+	hemisphere := positive
+	if v < 0 {
+		hemisphere = negative
+	}
+	// In hundredths of second, so rounding carries into minutes and degrees.
+	total := int64(math.Round(math.Abs(v) * 360000))
+	degrees := total / 360000
+	minutes := total % 360000 / 6000
+	seconds := float64(total%6000) / 100
+	return fmt.Sprintf("%d°%d'%s\"%c", degrees, minutes, strconv.FormatFloat(seconds, 'f', -1, 64), hemisphere)
+}
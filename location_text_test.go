@@ -0,0 +1,150 @@
+package am_test
+
+import (
+	"math"
+	"testing"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		in   string
+		want am.Location
+	}{
+		{"37.33,-122.03", am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{" 37.33, -122.03 ", am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{"37.33 -122.03", am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{`37°19'48"N 122°1'48"W`, am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{`37°19'48"N, 122°1'48"W`, am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{`122°1'48"W 37°19'48"N`, am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{"37°19′48″N 122°1′48″W", am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{"37°19'48''N 122°1'48''W", am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{"N 37° 19.8' W 122° 1.8'", am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{"37 19 48 N 122 1 48 W", am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{"37N 122W", am.Location{Latitude: 37, Longitude: -122}},
+		{`33°52'S 151°12.6'E`, am.Location{Latitude: -(33 + 52.0/60), Longitude: 151.21}},
+		{`37°19'48" -122°1'48"`, am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{"POINT(-122.03 37.33)", am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{"point ( -122.03  37.33 )", am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{"POINT Z (-122.03 37.33 12)", am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{"geo:37.33,-122.03", am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{"GEO:37.33,-122.03,12;u=35;crs=WGS84", am.Location{Latitude: 37.33, Longitude: -122.03}},
+		{"ezs42", am.Location{Latitude: 42.60498046875, Longitude: -5.60302734375}},
+		{"geohash:ezs42", am.Location{Latitude: 42.60498046875, Longitude: -5.60302734375}},
+		{"geohash:s", am.Location{Latitude: 22.5, Longitude: 22.5}},
+		{"37n122w", am.Location{Latitude: 37, Longitude: -122}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := am.ParseLocation(tt.in)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.InDelta(t, tt.want.Latitude, got.Latitude, 1e-9)
+			assert.InDelta(t, tt.want.Longitude, got.Longitude, 1e-9)
+		})
+	}
+}
+
+func TestParseLocation_Invalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"   ",
+		"37.33",
+		"37.33,-122.03,1",
+		"91,0",
+		"0,181",
+		"NaN,0",
+		`-33°52'S 151°E`,
+		`37°61'N 122°W`,
+		`37°19'60"N 122°W`,
+		`37.5°19'N 122°W`,
+		`37°N 122°N`,
+		`37°N`,
+		`19'N 122°W`,
+		`37°-19'N 122°W`,
+		"37°19'48\"N 122°1'48\"W 3°",
+		"N W",
+		"hello world",
+		"POINT(1)",
+		"POINT(1 2",
+		"POINT EMPTY",
+		"geo:1",
+		"geo:1,2;crs=gcj02",
+		"ezs42a",
+		"9q9hrh9q9hrh9",
+		"geohash:",
+		"geohash:9q9hra",
+		// Geohashes without a letter other than n, s, e and w need the
+		// prefix.
+		"123",
+		"s",
+		"news",
+		// Numbers without units or hemispheres.
+		"12 34 56 78",
+		"12 34, 56 7",
+	} {
+		_, err := am.ParseLocation(in)
+		assert.ErrorIs(t, err, am.ErrInvalidLocation, in)
+	}
+}
+
+func TestLocation_Format(t *testing.T) {
+	loc := am.Location{Latitude: 37.33, Longitude: -122.03}
+	assert.Equal(t, "37.33,-122.03", loc.Format(am.LocationFormatDecimal))
+	assert.Equal(t, `37°19'48"N 122°1'48"W`, loc.Format(am.LocationFormatDMS))
+	assert.Equal(t, "geo:37.33,-122.03", loc.Format(am.LocationFormatGeoURI))
+	assert.Equal(t, "POINT(-122.03 37.33)", loc.Format(am.LocationFormatWKT))
+	assert.Equal(t, "37.33,-122.03", loc.Format(am.LocationFormat(42)))
+
+	// Rounding carries into minutes and degrees.
+	loc = am.Location{Latitude: -(9 + 59.0/60 + 59.9999/3600), Longitude: 0}
+	assert.Equal(t, `10°0'0"S 0°0'0"E`, loc.Format(am.LocationFormatDMS))
+}
+
+func FuzzParseLocation(f *testing.F) {
+	for _, seed := range []string{
+		"37.33,-122.03",
+		`37°19'55"N 122°1'48"W`,
+		"N 37° 19.9' W 122° 1.8'",
+		"POINT(-122.03 37.33)",
+		"geo:37.33,-122.03;u=35",
+		"9q9hrh",
+		"-0,-0",
+		"1e2,1e-2",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, in string) {
+		loc, err := am.ParseLocation(in)
+		if err != nil {
+			if loc != (am.Location{}) {
+				t.Errorf("ParseLocation(%q) returned %v with error %v", in, loc, err)
+			}
+			return
+		}
+		if math.IsNaN(loc.Latitude) || math.Abs(loc.Latitude) > 90 ||
+			math.IsNaN(loc.Longitude) || math.Abs(loc.Longitude) > 180 {
+			t.Fatalf("ParseLocation(%q) = %v, out of range", in, loc)
+		}
+		for _, style := range []am.LocationFormat{am.LocationFormatDecimal, am.LocationFormatGeoURI, am.LocationFormatWKT} {
+			s := loc.Format(style)
+			again, err := am.ParseLocation(s)
+			if err != nil || again != loc {
+				t.Fatalf("ParseLocation(%q) = %v, %v, want %v", s, again, err, loc)
+			}
+		}
+		s := loc.Format(am.LocationFormatDMS)
+		again, err := am.ParseLocation(s)
+		if err != nil {
+			t.Fatalf("ParseLocation(%q): %v", s, err)
+		}
+		// Seconds are rounded to the hundredth.
+		if math.Abs(again.Latitude-loc.Latitude) > 0.005/3600+1e-12 ||
+			math.Abs(again.Longitude-loc.Longitude) > 0.005/3600+1e-12 {
+			t.Fatalf("ParseLocation(%q) = %v, want about %v", s, again, loc)
+		}
+	})
+}
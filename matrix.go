@@ -0,0 +1,109 @@
+package am
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// TravelMatrix holds the travel times and distances from every origin to
+// every destination. Cells are indexed [origin][destination].
+type TravelMatrix struct {
+	Origins      []Location
+	Destinations []Location
+
+	// The expected travel times, including delays due to traffic. 0 where
+	// Errors is set.
+	Durations [][]time.Duration
+
+	// The distances in meters. 0 where Errors is set.
+	Distances [][]int64
+
+	// The error of every cell, nil for the ones computed.
+	Errors [][]error
+}
+
+// Matrix returns the travel times and distances from every origin to every
+// destination with client.Eta, with an error per cell. A zero departure is
+// now.
+func Matrix(ctx context.Context, client Client, origins, destinations []Location, transportType TransportType, departure time.Time) *TravelMatrix {
+	m := &TravelMatrix{
+		Origins:      origins,
+		Destinations: destinations,
+		Durations:    make([][]time.Duration, len(origins)),
+		Distances:    make([][]int64, len(origins)),
+		Errors:       make([][]error, len(origins)),
+	}
+	opts := &EtaManyOptions{TransportType: transportType, DepartureDate: departure}
+	var reqs []EtaRequest
+	for i, origin := range origins {
+		m.Durations[i] = make([]time.Duration, len(destinations))
+		m.Distances[i] = make([]int64, len(destinations))
+		m.Errors[i] = make([]error, len(destinations))
+		reqs = append(reqs, etaChunks(origin, destinations, opts)...)
+	}
+	if len(reqs) == 0 {
+		return m
+	}
+
+	// All the requests of all the origins share the concurrency, chunks
+	// follow each other origin after origin.
+	chunksPerOrigin := len(reqs) / len(origins)
+	for i, chunk := range runEtaChunks(ctx, client, reqs, 0) {
+		origin := i / chunksPerOrigin
+		start := i % chunksPerOrigin * maxEtaDestinations
+		for _, result := range chunk {
+			j := start + result.Index
+			if result.Err != nil {
+				m.Errors[origin][j] = result.Err
+				continue
+			}
+			if result.Missing {
+				m.Errors[origin][j] = ErrEtaMissing
+				continue
+			}
+			m.Durations[origin][j] = time.Duration(result.Eta.ExpectedTravelTimeSeconds) * time.Second
+			m.Distances[origin][j] = result.Eta.DistanceMeters
+		}
+	}
+	return m
+}
+
+// WriteCSV writes the matrix as CSV, one line per cell after a header:
+//
+//	origin_index,origin,destination_index,destination,duration_seconds,distance_meters,error
+//
+// Locations are written as "latitude,longitude", the duration and distance
+// are empty for cells with an error.
+func (m *TravelMatrix) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{
+		"origin_index", "origin",
+		"destination_index", "destination",
+		"duration_seconds", "distance_meters", "error",
+	}); err != nil {
+		return err
+	}
+	for i, origin := range m.Origins {
+		for j, destination := range m.Destinations {
+			record := []string{
+				strconv.Itoa(i), origin.QueryString(),
+				strconv.Itoa(j), destination.QueryString(),
+				"", "", "",
+			}
+			if err := m.Errors[i][j]; err != nil {
+				record[6] = err.Error()
+			} else {
+				record[4] = strconv.FormatInt(int64(m.Durations[i][j]/time.Second), 10)
+				record[5] = strconv.FormatInt(m.Distances[i][j], 10)
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,97 @@
+package am_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMatrixTestClient serves ETAs of (origin latitude * 100 + destination
+// latitude) seconds and ten times as many meters. Requests from origin 2 to
+// destination 11 fail, and origin 1 never gets the ETA of destination 5.
+func newMatrixTestClient(t *testing.T, requests *atomic.Int64) am.Client {
+	return newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		query := r.URL.Query()
+		origin, err := am.ParseLocation(query.Get("origin"))
+		assert.NoError(t, err)
+		assert.Equal(t, "Automobile", query.Get("transportType"))
+		assert.Equal(t, "2024-01-02T03:04:05Z", query.Get("departureDate"))
+
+		etas := []map[string]any{}
+		for _, s := range strings.Split(query.Get("destinations"), "|") {
+			destination, err := am.ParseLocation(s)
+			assert.NoError(t, err)
+			if origin.Latitude == 2 && destination.Latitude == 11 {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write(expectErrorResponse1)
+				return
+			}
+			if origin.Latitude == 1 && destination.Latitude == 5 {
+				continue
+			}
+			seconds := int(origin.Latitude*100 + destination.Latitude)
+			etas = append(etas, map[string]any{
+				"destination":               destination,
+				"distanceMeters":            seconds * 10,
+				"expectedTravelTimeSeconds": seconds,
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"etas": etas})
+	})
+}
+
+func TestMatrix(t *testing.T) {
+	var requests atomic.Int64
+	client := newMatrixTestClient(t, &requests)
+
+	origins := []am.Location{{Latitude: 0, Longitude: 1}, {Latitude: 1, Longitude: 1}, {Latitude: 2, Longitude: 1}}
+	destinations := make([]am.Location, 12)
+	for i := range destinations {
+		destinations[i] = am.Location{Latitude: float64(i), Longitude: 2}
+	}
+	departure := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	m := am.Matrix(context.Background(), client, origins, destinations, am.TransportTypeAutomobile, departure)
+	assert.Equal(t, int64(6), requests.Load())
+	assert.Len(t, m.Durations, 3)
+	for i := range origins {
+		assert.Len(t, m.Durations[i], 12)
+		for j := range destinations {
+			switch {
+			case i == 2 && j >= 10:
+				var apiErr *am.ErrorFromAPI
+				assert.True(t, errors.As(m.Errors[i][j], &apiErr))
+				assert.Zero(t, m.Durations[i][j])
+			case i == 1 && j == 5:
+				assert.ErrorIs(t, m.Errors[i][j], am.ErrEtaMissing)
+			default:
+				assert.NoError(t, m.Errors[i][j])
+				assert.Equal(t, time.Duration(i*100+j)*time.Second, m.Durations[i][j])
+				assert.Equal(t, int64(i*100+j)*10, m.Distances[i][j])
+			}
+		}
+	}
+
+	sb := &strings.Builder{}
+	assert.NoError(t, m.WriteCSV(sb))
+	lines := strings.Split(sb.String(), "\n")
+	assert.Len(t, lines, 1+3*12+1)
+	assert.Equal(t, "origin_index,origin,destination_index,destination,duration_seconds,distance_meters,error", lines[0])
+	assert.Equal(t, `0,"0,1",3,"3,2",3,30,`, lines[4])
+	assert.Equal(t, `1,"1,1",5,"5,2",,,am: no ETA returned for the destination`, lines[1+12+5])
+
+	m = am.Matrix(context.Background(), client, origins, nil, am.TransportTypeAutomobile, departure)
+	assert.Len(t, m.Durations, 3)
+	assert.Empty(t, m.Durations[0])
+	m = am.Matrix(context.Background(), client, nil, destinations, am.TransportTypeAutomobile, departure)
+	assert.Empty(t, m.Durations)
+	assert.Equal(t, int64(6), requests.Load())
+}
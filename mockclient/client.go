@@ -11,6 +11,7 @@ import (
 
 	am "github.com/ringsaturn/am"
 	gomock "go.uber.org/mock/gomock"
+	language "golang.org/x/text/language"
 )
 
 // MockAccessTokenSaver is a mock of AccessTokenSaver interface.
@@ -89,6 +90,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// AlternateIDs mocks base method.
+func (m *MockClient) AlternateIDs(arg0 context.Context, arg1 *am.AlternateIDsRequest) (*am.AlternateIDsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AlternateIDs", arg0, arg1)
+	ret0, _ := ret[0].(*am.AlternateIDsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AlternateIDs indicates an expected call of AlternateIDs.
+func (mr *MockClientMockRecorder) AlternateIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AlternateIDs", reflect.TypeOf((*MockClient)(nil).AlternateIDs), arg0, arg1)
+}
+
 // Directions mocks base method.
 func (m *MockClient) Directions(arg0 context.Context, arg1 *am.DirectionsRequest) (*am.DirectionsResponse, error) {
 	m.ctrl.T.Helper()
@@ -165,6 +181,51 @@ func (mr *MockClientMockRecorder) GetNewAccessToken(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewAccessToken", reflect.TypeOf((*MockClient)(nil).GetNewAccessToken), arg0)
 }
 
+// Place mocks base method.
+func (m *MockClient) Place(arg0 context.Context, arg1 *am.PlaceRequest) (*am.Place, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Place", arg0, arg1)
+	ret0, _ := ret[0].(*am.Place)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Place indicates an expected call of Place.
+func (mr *MockClientMockRecorder) Place(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Place", reflect.TypeOf((*MockClient)(nil).Place), arg0, arg1)
+}
+
+// Places mocks base method.
+func (m *MockClient) Places(arg0 context.Context, arg1 *am.PlacesRequest) (*am.PlacesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Places", arg0, arg1)
+	ret0, _ := ret[0].(*am.PlacesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Places indicates an expected call of Places.
+func (mr *MockClientMockRecorder) Places(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Places", reflect.TypeOf((*MockClient)(nil).Places), arg0, arg1)
+}
+
+// ResolveCompletion mocks base method.
+func (m *MockClient) ResolveCompletion(ctx context.Context, result *am.AutocompleteResult, lang language.Tag) (*am.SearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveCompletion", ctx, result, lang)
+	ret0, _ := ret[0].(*am.SearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveCompletion indicates an expected call of ResolveCompletion.
+func (mr *MockClientMockRecorder) ResolveCompletion(ctx, result, lang interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveCompletion", reflect.TypeOf((*MockClient)(nil).ResolveCompletion), ctx, result, lang)
+}
+
 // ReverseGeocode mocks base method.
 func (m *MockClient) ReverseGeocode(arg0 context.Context, arg1 *am.ReverseRequest) (*am.PlaceResults, error) {
 	m.ctrl.T.Helper()
@@ -224,6 +285,43 @@ func (mr *MockClientMockRecorder) SetAccessToken(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccessToken", reflect.TypeOf((*MockClient)(nil).SetAccessToken), arg0, arg1, arg2)
 }
 
+// MockRateLimiter is a mock of RateLimiter interface.
+type MockRateLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimiterMockRecorder
+}
+
+// MockRateLimiterMockRecorder is the mock recorder for MockRateLimiter.
+type MockRateLimiterMockRecorder struct {
+	mock *MockRateLimiter
+}
+
+// NewMockRateLimiter creates a new mock instance.
+func NewMockRateLimiter(ctrl *gomock.Controller) *MockRateLimiter {
+	mock := &MockRateLimiter{ctrl: ctrl}
+	mock.recorder = &MockRateLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimiter) EXPECT() *MockRateLimiterMockRecorder {
+	return m.recorder
+}
+
+// Wait mocks base method.
+func (m *MockRateLimiter) Wait(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Wait", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Wait indicates an expected call of Wait.
+func (mr *MockRateLimiterMockRecorder) Wait(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockRateLimiter)(nil).Wait), ctx)
+}
+
 // Mockquery is a mock of query interface.
 type Mockquery struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,53 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// loadCheckpoint returns the number of input rows already written, 0 if the
+// checkpoint file doesn't exist yet.
+func loadCheckpoint(path string) (int, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("pipeline: read checkpoint: %w", err)
+	}
+	done, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil || done < 0 {
+		return 0, fmt.Errorf("pipeline: invalid checkpoint %s: %q", path, b)
+	}
+	return done, nil
+}
+
+// saveCheckpoint replaces the checkpoint file atomically, a crash leaves
+// either the previous or the new count.
+func saveCheckpoint(path string, done int) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("pipeline: write checkpoint: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := fmt.Fprintf(f, "%d\n", done); err != nil {
+		f.Close()
+		return fmt.Errorf("pipeline: write checkpoint: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("pipeline: write checkpoint: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("pipeline: write checkpoint: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("pipeline: write checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,388 @@
+// Package pipeline geocodes CSV and JSON Lines files with an am.Client.
+//
+// Rows are streamed: they are read in chunks, geocoded concurrently and
+// written in input order with the match appended, so files of any size can
+// be processed. With a checkpoint file, an interrupted run resumes after the
+// last row written instead of geocoding the whole file again.
+//
+//	in, _ := os.Open("addresses.csv")
+//	out, _ := os.OpenFile("geocoded.csv", os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+//	stats, err := pipeline.Run(ctx, client, in, out, &pipeline.Config{
+//		Columns:    pipeline.Columns{Query: []string{"street", "city", "zip"}},
+//		Checkpoint: "geocoded.csv.checkpoint",
+//	})
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/biter777/countries"
+	"github.com/ringsaturn/am"
+	"golang.org/x/text/language"
+)
+
+const defaultChunkSize = 100
+
+// The fields appended to every output row. A field of the input with the same
+// name is replaced.
+const (
+	// The latitude of the best match, empty if there is none.
+	ColumnLatitude = "match_latitude"
+
+	// The longitude of the best match, empty if there is none.
+	ColumnLongitude = "match_longitude"
+
+	// The address lines of the best match joined with ", ".
+	ColumnFormattedAddress = "formatted_address"
+
+	// The 2-letter country code of the best match.
+	ColumnCountryCode = "country_code"
+
+	// How unambiguous the match is, between 0 and 1. The API doesn't score
+	// matches, the confidence is 1 divided by the number of results: 1 for a
+	// single result, lower when several candidates compete, 0 for no result.
+	ColumnConfidence = "confidence"
+
+	// Why the row couldn't be geocoded, empty on success.
+	ColumnError = "error"
+)
+
+var outputColumns = []string{
+	ColumnLatitude,
+	ColumnLongitude,
+	ColumnFormattedAddress,
+	ColumnCountryCode,
+	ColumnConfidence,
+	ColumnError,
+}
+
+// Format is the format of the input or the output.
+type Format int
+
+const (
+	// CSV with a header line naming the columns.
+	CSV Format = iota
+
+	// JSON Lines, one object per line, fields are named by the keys.
+	JSONL
+)
+
+// Mode selects the request made for each row.
+type Mode int
+
+const (
+	// Geocode addresses with am.BatchGeocode.
+	Geocode Mode = iota
+
+	// Reverse geocode coordinates with am.BatchReverseGeocode.
+	Reverse
+)
+
+// Columns maps the fields of the input rows to the requests. Fields are
+// columns of the header for CSV and keys for JSON Lines.
+type Columns struct {
+	// (Required for Geocode) The fields making the address, joined with
+	// ", " in order, empty ones left out.
+	Query []string
+
+	// (Reverse) A field with the coordinates in any form ParseLocation
+	// accepts. Takes precedence over Latitude and Longitude.
+	Location string
+
+	// (Reverse) The fields of the latitude and longitude, used if Location is
+	// empty.
+	Latitude  string
+	Longitude string
+
+	// (Optional) A field with the BCP 47 language of the results of the row,
+	// Config.Lang is used where it is empty.
+	Lang string
+
+	// (Optional, Geocode) A field with comma-separated 2-letter country codes
+	// to limit the results of the row to.
+	Countries string
+}
+
+// Config configures Run.
+type Config struct {
+	// Geocode by default.
+	Mode Mode
+
+	// The formats of the input and the output, CSV by default. They may
+	// differ, the CSV columns written from JSON Lines are the keys of the
+	// first row.
+	Input  Format
+	Output Format
+
+	Columns Columns
+
+	// The language of the results, for rows without a Lang field.
+	Lang language.Tag
+
+	// (Optional) The path of the checkpoint file, which counts the input rows
+	// written. If it exists, the rows it counts are skipped and no CSV header
+	// is written: open the output in append mode to resume. It is updated
+	// after each chunk is written, a crash between both may write the rows of
+	// the chunk twice, never lose them.
+	Checkpoint string
+
+	// The number of requests in flight, see am.BatchOptions.
+	Concurrency int
+
+	// The number of rows geocoded between two checkpoints, 100 by default.
+	ChunkSize int
+}
+
+// Stats counts the rows of a run.
+type Stats struct {
+	// Rows skipped because the checkpoint counts them.
+	Skipped int
+
+	// Rows with at least one result.
+	Matched int
+
+	// Rows without result.
+	NoMatch int
+
+	// Rows whose fields are invalid or whose request failed, their error is
+	// in the error field.
+	Failed int
+}
+
+// Run geocodes the rows read from r and writes them to w with the fields of
+// the match appended. Rows that fail are written with their error, only
+// errors reading, writing or checkpointing stop the run. When ctx is done,
+// the rows geocoded so far are written and checkpointed and ctx.Err() is
+// returned.
+func Run(ctx context.Context, client am.Client, r io.Reader, w io.Writer, cfg *Config) (*Stats, error) {
+	if cfg == nil {
+		return nil, errors.New("pipeline: nil config")
+	}
+	switch cfg.Mode {
+	case Geocode:
+		if len(cfg.Columns.Query) == 0 {
+			return nil, errors.New("pipeline: no query column")
+		}
+	case Reverse:
+		if cfg.Columns.Location == "" && (cfg.Columns.Latitude == "" || cfg.Columns.Longitude == "") {
+			return nil, errors.New("pipeline: no location or latitude and longitude columns")
+		}
+	default:
+		return nil, fmt.Errorf("pipeline: unknown mode %d", cfg.Mode)
+	}
+	reader, err := newRowReader(cfg.Input, r)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := newRowWriter(cfg.Output, w)
+	if err != nil {
+		return nil, err
+	}
+	done := 0
+	if cfg.Checkpoint != "" {
+		if done, err = loadCheckpoint(cfg.Checkpoint); err != nil {
+			return nil, err
+		}
+	}
+	chunkSize := cfg.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+
+	p := &run{ctx: ctx, client: client, cfg: cfg, writer: writer, done: done, stats: &Stats{}}
+	chunk := make([]*record, 0, chunkSize)
+	for read := 0; ; read++ {
+		row, err := reader.read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return p.stats, err
+		}
+		if read == 0 {
+			first := &record{keys: append([]string(nil), row.keys...), values: make([]any, len(row.keys))}
+			for _, column := range outputColumns {
+				first.set(column, nil)
+			}
+			if err := writer.init(first, done == 0); err != nil {
+				return p.stats, fmt.Errorf("pipeline: write: %w", err)
+			}
+		}
+		if read < done {
+			p.stats.Skipped++
+			continue
+		}
+		chunk = append(chunk, row)
+		if len(chunk) == chunkSize {
+			if err := p.process(chunk); err != nil {
+				return p.stats, err
+			}
+			chunk = chunk[:0]
+		}
+	}
+	if err := p.process(chunk); err != nil {
+		return p.stats, err
+	}
+	if err := writer.flush(); err != nil {
+		return p.stats, fmt.Errorf("pipeline: write: %w", err)
+	}
+	return p.stats, nil
+}
+
+type run struct {
+	ctx    context.Context
+	client am.Client
+	cfg    *Config
+	writer rowWriter
+	done   int
+	stats  *Stats
+}
+
+// process geocodes, writes and checkpoints a chunk of rows.
+func (p *run) process(chunk []*record) error {
+	if len(chunk) == 0 {
+		return nil
+	}
+	errs := make([]error, len(chunk))
+	skip := func(index int) bool { return errs[index] != nil }
+	var results []am.BatchResult[am.PlaceResults]
+	switch p.cfg.Mode {
+	case Geocode:
+		reqs := make([]am.GeocodeRequest, len(chunk))
+		for i, row := range chunk {
+			errs[i] = p.geocodeRequest(row, &reqs[i])
+		}
+		results = am.BatchGeocode(p.ctx, p.client, reqs, &am.BatchOptions[am.PlaceResults]{Concurrency: p.cfg.Concurrency, Skip: skip})
+	case Reverse:
+		reqs := make([]am.ReverseRequest, len(chunk))
+		for i, row := range chunk {
+			errs[i] = p.reverseRequest(row, &reqs[i])
+		}
+		results = am.BatchReverseGeocode(p.ctx, p.client, reqs, &am.BatchOptions[am.PlaceResults]{Concurrency: p.cfg.Concurrency, Skip: skip})
+	}
+
+	written := 0
+	for i, row := range chunk {
+		err := errs[i]
+		var resp *am.PlaceResults
+		if err == nil && i < len(results) {
+			resp, err = results[i].Response, results[i].Err
+		}
+		if ctxErr := p.ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+			break
+		}
+		p.enrich(row, resp, err)
+		if err := p.writer.write(row); err != nil {
+			return fmt.Errorf("pipeline: write: %w", err)
+		}
+		written++
+	}
+	if err := p.writer.flush(); err != nil {
+		return fmt.Errorf("pipeline: write: %w", err)
+	}
+	p.done += written
+	if p.cfg.Checkpoint != "" {
+		if err := saveCheckpoint(p.cfg.Checkpoint, p.done); err != nil {
+			return err
+		}
+	}
+	if written < len(chunk) {
+		return p.ctx.Err()
+	}
+	return nil
+}
+
+func (p *run) lang(row *record) (language.Tag, error) {
+	if p.cfg.Columns.Lang == "" {
+		return p.cfg.Lang, nil
+	}
+	value := strings.TrimSpace(row.get(p.cfg.Columns.Lang))
+	if value == "" {
+		return p.cfg.Lang, nil
+	}
+	tag, err := language.Parse(value)
+	if err != nil {
+		return language.Tag{}, fmt.Errorf("pipeline: invalid language %q", value)
+	}
+	return tag, nil
+}
+
+func (p *run) geocodeRequest(row *record, req *am.GeocodeRequest) error {
+	parts := make([]string, 0, len(p.cfg.Columns.Query))
+	for _, column := range p.cfg.Columns.Query {
+		if value := strings.TrimSpace(row.get(column)); value != "" {
+			parts = append(parts, value)
+		}
+	}
+	if len(parts) == 0 {
+		return errors.New("pipeline: empty query")
+	}
+	req.Query = strings.Join(parts, ", ")
+
+	lang, err := p.lang(row)
+	if err != nil {
+		return err
+	}
+	req.Lang = lang
+
+	if p.cfg.Columns.Countries != "" {
+		for _, code := range strings.Split(row.get(p.cfg.Columns.Countries), ",") {
+			code = strings.TrimSpace(code)
+			if code == "" {
+				continue
+			}
+			country := countries.ByName(code)
+			if !strings.EqualFold(country.Alpha2(), code) {
+				return fmt.Errorf("pipeline: unknown country %q", code)
+			}
+			req.LimitToCountries = append(req.LimitToCountries, country)
+		}
+	}
+	return nil
+}
+
+func (p *run) reverseRequest(row *record, req *am.ReverseRequest) error {
+	s := row.get(p.cfg.Columns.Location)
+	if p.cfg.Columns.Location == "" {
+		s = row.get(p.cfg.Columns.Latitude) + "," + row.get(p.cfg.Columns.Longitude)
+	}
+	loc, err := am.ParseLocation(s)
+	if err != nil {
+		return err
+	}
+	req.Loc = &loc
+
+	lang, err := p.lang(row)
+	if err != nil {
+		return err
+	}
+	req.Lang = lang
+	return nil
+}
+
+// enrich sets the output fields of the row from its best match.
+func (p *run) enrich(row *record, resp *am.PlaceResults, err error) {
+	for _, column := range outputColumns {
+		row.set(column, nil)
+	}
+	switch {
+	case err != nil:
+		p.stats.Failed++
+		row.set(ColumnError, err.Error())
+	case resp == nil || len(resp.Results) == 0:
+		p.stats.NoMatch++
+		row.set(ColumnConfidence, 0.0)
+	default:
+		p.stats.Matched++
+		place := resp.Results[0]
+		row.set(ColumnLatitude, place.Coordinate.Latitude)
+		row.set(ColumnLongitude, place.Coordinate.Longitude)
+		row.set(ColumnFormattedAddress, strings.Join(place.FormattedAddressLines, ", "))
+		row.set(ColumnCountryCode, place.CountryCode)
+		row.set(ColumnConfidence, 1/float64(len(resp.Results)))
+	}
+}
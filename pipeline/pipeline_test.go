@@ -0,0 +1,209 @@
+package pipeline_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/biter777/countries"
+	"github.com/ringsaturn/am"
+	"github.com/ringsaturn/am/mockclient"
+	"github.com/ringsaturn/am/pipeline"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var cupertino = am.Place{
+	Coordinate:            am.Location{Latitude: 37.3349, Longitude: -122.009},
+	FormattedAddressLines: []string{"1 Apple Park Way", "Cupertino, CA 95014"},
+	CountryCode:           "US",
+}
+
+func fakeGeocode(_ context.Context, req *am.GeocodeRequest) (*am.PlaceResults, error) {
+	switch {
+	case strings.HasPrefix(req.Query, "1 Apple Park Way"):
+		return &am.PlaceResults{Results: []am.Place{cupertino}}, nil
+	case strings.HasPrefix(req.Query, "Main Street"):
+		return &am.PlaceResults{Results: []am.Place{cupertino, cupertino}}, nil
+	case strings.HasPrefix(req.Query, "fail"):
+		return nil, errors.New("am: 500 Internal Server Error")
+	default:
+		return &am.PlaceResults{}, nil
+	}
+}
+
+const addressesCSV = `id,street,city,lang
+1,1 Apple Park Way,Cupertino,en-US
+2,Main Street,,
+3,Nowhere,,
+4,,,
+5,fail,,
+6,1 Apple Park Way,,not a language
+`
+
+const geocodedCSV = `id,street,city,lang,match_latitude,match_longitude,formatted_address,country_code,confidence,error
+1,1 Apple Park Way,Cupertino,en-US,37.3349,-122.009,"1 Apple Park Way, Cupertino, CA 95014",US,1,
+2,Main Street,,,37.3349,-122.009,"1 Apple Park Way, Cupertino, CA 95014",US,0.5,
+3,Nowhere,,,,,,,0,
+4,,,,,,,,,pipeline: empty query
+5,fail,,,,,,,,am: 500 Internal Server Error
+6,1 Apple Park Way,,not a language,,,,,,"pipeline: invalid language ""not a language"""
+`
+
+func TestRun_CSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mockclient.NewMockClient(ctrl)
+	var queries []string
+	client.EXPECT().Geocode(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *am.GeocodeRequest) (*am.PlaceResults, error) {
+			queries = append(queries, req.Query)
+			return fakeGeocode(ctx, req)
+		},
+	).Times(4)
+
+	out := &bytes.Buffer{}
+	stats, err := pipeline.Run(context.Background(), client, strings.NewReader(addressesCSV), out, &pipeline.Config{
+		Columns:     pipeline.Columns{Query: []string{"street", "city"}, Lang: "lang"},
+		ChunkSize:   4,
+		Concurrency: 1,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, geocodedCSV, out.String())
+	assert.Equal(t, &pipeline.Stats{Matched: 2, NoMatch: 1, Failed: 3}, stats)
+	assert.Equal(t, []string{"1 Apple Park Way, Cupertino", "Main Street", "Nowhere", "fail"}, queries)
+}
+
+func TestRun_Resume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mockclient.NewMockClient(ctrl)
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+	cfg := &pipeline.Config{
+		Columns:     pipeline.Columns{Query: []string{"street", "city"}, Lang: "lang"},
+		Checkpoint:  checkpoint,
+		ChunkSize:   2,
+		Concurrency: 1,
+	}
+
+	// The first run is interrupted while geocoding the second chunk.
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int
+	client.EXPECT().Geocode(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *am.GeocodeRequest) (*am.PlaceResults, error) {
+			calls++
+			if req.Query == "Nowhere" {
+				cancel()
+				return nil, context.Canceled
+			}
+			return fakeGeocode(ctx, req)
+		},
+	).Times(3)
+	out := &bytes.Buffer{}
+	stats, err := pipeline.Run(ctx, client, strings.NewReader(addressesCSV), out, cfg)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, &pipeline.Stats{Matched: 2}, stats)
+	assert.Equal(t, 3, calls)
+	b, err := os.ReadFile(checkpoint)
+	assert.NoError(t, err)
+	assert.Equal(t, "2\n", string(b))
+
+	// The second run appends the rows left.
+	calls = 0
+	client.EXPECT().Geocode(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *am.GeocodeRequest) (*am.PlaceResults, error) {
+			calls++
+			return fakeGeocode(ctx, req)
+		},
+	).Times(2)
+	stats, err = pipeline.Run(context.Background(), client, strings.NewReader(addressesCSV), out, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, &pipeline.Stats{Skipped: 2, NoMatch: 1, Failed: 3}, stats)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, geocodedCSV, out.String())
+
+	// Nothing left to do.
+	stats, err = pipeline.Run(context.Background(), client, strings.NewReader(addressesCSV), out, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, &pipeline.Stats{Skipped: 6}, stats)
+	assert.Equal(t, geocodedCSV, out.String())
+}
+
+func TestRun_ReverseJSONL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mockclient.NewMockClient(ctrl)
+	client.EXPECT().ReverseGeocode(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *am.ReverseRequest) (*am.PlaceResults, error) {
+			assert.Equal(t, am.Location{Latitude: 37.3349, Longitude: -122.009}, *req.Loc)
+			return &am.PlaceResults{Results: []am.Place{cupertino}}, nil
+		},
+	)
+
+	in := `{"name": "HQ", "lat": 37.3349, "lon": -122.009, "tags": ["a"]}
+
+{"name": "Nowhere", "lat": 91, "lon": 0}
+`
+	cfg := &pipeline.Config{
+		Mode:    pipeline.Reverse,
+		Input:   pipeline.JSONL,
+		Output:  pipeline.JSONL,
+		Columns: pipeline.Columns{Latitude: "lat", Longitude: "lon"},
+	}
+	out := &bytes.Buffer{}
+	stats, err := pipeline.Run(context.Background(), client, strings.NewReader(in), out, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, &pipeline.Stats{Matched: 1, Failed: 1}, stats)
+	lines := strings.Split(out.String(), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, `{"name":"HQ","lat":37.3349,"lon":-122.009,"tags":["a"],"match_latitude":37.3349,"match_longitude":-122.009,"formatted_address":"1 Apple Park Way, Cupertino, CA 95014","country_code":"US","confidence":1,"error":null}`, lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], `{"name":"Nowhere","lat":91,"lon":0,"match_latitude":null,`), lines[1])
+	assert.Contains(t, lines[1], `"error":"am: invalid location`)
+}
+
+func TestRun_JSONLToCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mockclient.NewMockClient(ctrl)
+	client.EXPECT().Geocode(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *am.GeocodeRequest) (*am.PlaceResults, error) {
+			assert.Equal(t, []countries.CountryCode{countries.US}, req.LimitToCountries)
+			return fakeGeocode(ctx, req)
+		},
+	)
+
+	in := `{"address": "1 Apple Park Way", "countries": "us"}
+{"countries": "US, XX", "address": "Main Street"}
+`
+	cfg := &pipeline.Config{
+		Input:   pipeline.JSONL,
+		Columns: pipeline.Columns{Query: []string{"address"}, Countries: "countries"},
+	}
+	out := &bytes.Buffer{}
+	_, err := pipeline.Run(context.Background(), client, strings.NewReader(in), out, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, `address,countries,match_latitude,match_longitude,formatted_address,country_code,confidence,error
+1 Apple Park Way,us,37.3349,-122.009,"1 Apple Park Way, Cupertino, CA 95014",US,1,
+Main Street,"US, XX",,,,,,"pipeline: unknown country ""XX"""
+`, out.String())
+}
+
+func TestRun_Invalid(t *testing.T) {
+	client := mockclient.NewMockClient(gomock.NewController(t))
+	for _, cfg := range []*pipeline.Config{
+		nil,
+		{},
+		{Mode: pipeline.Reverse, Columns: pipeline.Columns{Latitude: "lat"}},
+		{Mode: pipeline.Mode(9)},
+		{Columns: pipeline.Columns{Query: []string{"q"}}, Input: pipeline.Format(9)},
+	} {
+		_, err := pipeline.Run(context.Background(), client, strings.NewReader(""), &bytes.Buffer{}, cfg)
+		assert.Error(t, err)
+	}
+
+	_, err := pipeline.Run(context.Background(), client, strings.NewReader("[1]\n"), &bytes.Buffer{}, &pipeline.Config{
+		Input:   pipeline.JSONL,
+		Columns: pipeline.Columns{Query: []string{"q"}},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,233 @@
+package pipeline
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// record is a row of the input, its fields keep the input order. Values are
+// strings for CSV and decoded JSON values for JSON Lines, numbers as
+// json.Number.
+type record struct {
+	keys   []string
+	values []any
+}
+
+// get returns the value of the field as a string, "" if missing.
+func (r *record) get(key string) string {
+	for i, k := range r.keys {
+		if k == key {
+			return stringify(r.values[i])
+		}
+	}
+	return ""
+}
+
+// set replaces the value of the field, or appends the field.
+func (r *record) set(key string, value any) {
+	for i, k := range r.keys {
+		if k == key {
+			r.values[i] = value
+			return
+		}
+	}
+	r.keys = append(r.keys, key)
+	r.values = append(r.values, value)
+}
+
+func stringify(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+type rowReader interface {
+	// read returns io.EOF after the last row.
+	read() (*record, error)
+}
+
+type rowWriter interface {
+	// init is called with the first row before any write. The CSV header is
+	// only written if header is true.
+	init(first *record, header bool) error
+	write(r *record) error
+	flush() error
+}
+
+func newRowReader(format Format, r io.Reader) (rowReader, error) {
+	switch format {
+	case CSV:
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+		return &csvReader{r: cr}, nil
+	case JSONL:
+		dec := json.NewDecoder(r)
+		dec.UseNumber()
+		return &jsonlReader{dec: dec}, nil
+	default:
+		return nil, fmt.Errorf("pipeline: unknown format %d", format)
+	}
+}
+
+func newRowWriter(format Format, w io.Writer) (rowWriter, error) {
+	switch format {
+	case CSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case JSONL:
+		return &jsonlWriter{w: w}, nil
+	default:
+		return nil, fmt.Errorf("pipeline: unknown format %d", format)
+	}
+}
+
+// csvReader reads CSV with a header line.
+type csvReader struct {
+	r      *csv.Reader
+	header []string
+}
+
+func (r *csvReader) read() (*record, error) {
+	if r.header == nil {
+		header, err := r.r.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("pipeline: read CSV header: %w", err)
+		}
+		r.header = header
+	}
+	fields, err := r.r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("pipeline: read CSV: %w", err)
+	}
+	out := &record{keys: make([]string, 0, len(r.header)), values: make([]any, 0, len(r.header))}
+	for i, key := range r.header {
+		var value string
+		if i < len(fields) {
+			value = fields[i]
+		}
+		out.keys = append(out.keys, key)
+		out.values = append(out.values, value)
+	}
+	return out, nil
+}
+
+// jsonlReader reads one JSON object per line. The decoder tolerates blank
+// lines and objects spanning lines.
+type jsonlReader struct {
+	dec  *json.Decoder
+	line int
+}
+
+func (r *jsonlReader) read() (*record, error) {
+	r.line++
+	tok, err := r.dec.Token()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("pipeline: read JSON Lines record %d: %w", r.line, err)
+	}
+	if tok != json.Delim('{') {
+		return nil, fmt.Errorf("pipeline: JSON Lines record %d is not an object", r.line)
+	}
+	out := &record{}
+	for r.dec.More() {
+		tok, err := r.dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("pipeline: read JSON Lines record %d: %w", r.line, err)
+		}
+		key, _ := tok.(string)
+		var value any
+		if err := r.dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("pipeline: read JSON Lines record %d: %w", r.line, err)
+		}
+		out.set(key, value)
+	}
+	if _, err := r.dec.Token(); err != nil {
+		return nil, fmt.Errorf("pipeline: read JSON Lines record %d: %w", r.line, err)
+	}
+	return out, nil
+}
+
+// csvWriter writes the columns of the first row, fields of later rows are
+// matched by name.
+type csvWriter struct {
+	w       *csv.Writer
+	columns []string
+}
+
+func (w *csvWriter) init(first *record, header bool) error {
+	w.columns = first.keys
+	if !header {
+		return nil
+	}
+	return w.w.Write(w.columns)
+}
+
+func (w *csvWriter) write(r *record) error {
+	fields := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		fields[i] = r.get(column)
+	}
+	return w.w.Write(fields)
+}
+
+func (w *csvWriter) flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type jsonlWriter struct {
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+func (w *jsonlWriter) init(first *record, header bool) error { return nil }
+
+func (w *jsonlWriter) write(r *record) error {
+	w.buf.WriteByte('{')
+	for i, key := range r.keys {
+		if i > 0 {
+			w.buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		v, err := json.Marshal(r.values[i])
+		if err != nil {
+			return err
+		}
+		w.buf.Write(k)
+		w.buf.WriteByte(':')
+		w.buf.Write(v)
+	}
+	w.buf.WriteString("}\n")
+	return nil
+}
+
+func (w *jsonlWriter) flush() error {
+	_, err := w.w.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
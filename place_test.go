@@ -0,0 +1,70 @@
+package am_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestClient_Place(t *testing.T) {
+	client := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/place/I63802885C8189B2B", r.URL.Path)
+		assert.Equal(t, "en-US", r.URL.Query().Get("lang"))
+		_, _ = w.Write(expectPlaceResponse1)
+	})
+	place, err := client.Place(context.Background(), &am.PlaceRequest{
+		ID:   "I63802885C8189B2B",
+		Lang: language.AmericanEnglish,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Apple Park", place.Name)
+
+	_, err = client.Place(context.Background(), &am.PlaceRequest{})
+	assert.Error(t, err)
+}
+
+func TestClient_PlaceEscapeID(t *testing.T) {
+	client := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/place/a%2Fb", r.URL.EscapedPath())
+		_, _ = w.Write(expectPlaceResponse1)
+	})
+	_, err := client.Place(context.Background(), &am.PlaceRequest{ID: "a/b"})
+	assert.NoError(t, err)
+}
+
+func TestClient_Places(t *testing.T) {
+	client := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/place", r.URL.Path)
+		assert.Equal(t, "I63802885C8189B2B,I0000000000000000", r.URL.Query().Get("ids"))
+		_, _ = w.Write(expectPlacesResponse1)
+	})
+	resp, err := client.Places(context.Background(), &am.PlacesRequest{
+		IDs: []string{"I63802885C8189B2B", "I0000000000000000"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(resp.Results))
+	assert.Equal(t, 1, len(resp.Errors))
+}
+
+func TestClient_AlternateIDs(t *testing.T) {
+	client := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/place/alternateIds", r.URL.Path)
+		assert.Equal(t, "I7F6A2D4E91C0B3A8,invalid", r.URL.Query().Get("ids"))
+		_, _ = w.Write(expectAlternateIDsResponse1)
+	})
+	resp, err := client.AlternateIDs(context.Background(), &am.AlternateIDsRequest{
+		IDs: []string{"I7F6A2D4E91C0B3A8", "invalid"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "I7F6A2D4E91C0B3A8", resp.Results[0].ID)
+}
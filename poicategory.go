@@ -0,0 +1,48 @@
+package am
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go run ./internal/cmd/genpoicategory -in poicategory.txt -out poicategory_gen.go
+
+// PoiCategory: 'A string that describes a specific point of interest (POI) category.'
+// https://developer.apple.com/documentation/applemapsserverapi/poicategory
+//
+// The constants are generated from poicategory.txt, see
+// PoiCategoryCatalogVersion for the catalog version.
+type PoiCategory string
+
+var poiCategoriesByLowerName = func() map[string]PoiCategory {
+	m := make(map[string]PoiCategory, len(allPoiCategories))
+	for _, poi := range allPoiCategories {
+		m[strings.ToLower(string(poi))] = poi
+	}
+	return m
+}()
+
+// AllPoiCategories returns every known PoiCategory, in catalog order.
+func AllPoiCategories() []PoiCategory {
+	return append([]PoiCategory(nil), allPoiCategories...)
+}
+
+// ParsePoiCategory matches s against the known categories case-insensitively,
+// so "evcharger" and "EVCharger" both return EVCharger.
+func ParsePoiCategory(s string) (PoiCategory, error) {
+	poi, ok := poiCategoriesByLowerName[strings.ToLower(strings.TrimSpace(s))]
+	if !ok {
+		return "", fmt.Errorf("am: unknown poi category %q", s)
+	}
+	return poi, nil
+}
+
+// IsValid reports whether poi is in the catalog. The match is case-sensitive,
+// use ParsePoiCategory to normalize user input first.
+func (poi PoiCategory) IsValid() bool {
+	return poiCategoriesByLowerName[strings.ToLower(string(poi))] == poi
+}
+
+func validatePoiCategories(include, exclude []PoiCategory) error {
+	return validateIncludeExclude("poi category", "PoiCategories", include, exclude, PoiCategory.IsValid)
+}
@@ -0,0 +1,165 @@
+// Code generated by genpoicategory from poicategory.txt. DO NOT EDIT.
+
+package am
+
+// PoiCategoryCatalogVersion is the version of the PoiCategory catalog
+// this package was generated from.
+const PoiCategoryCatalogVersion = "2024-06"
+
+const (
+	Airport          PoiCategory = "Airport"          // An airport.
+	AirportGate      PoiCategory = "AirportGate"      // A specific gate at an airport.
+	AirportTerminal  PoiCategory = "AirportTerminal"  // A specific named terminal at an airport.
+	AmusementPark    PoiCategory = "AmusementPark"    // An amusement park.
+	AnimalService    PoiCategory = "AnimalService"    // An animal service, such as a veterinarian or pet groomer.
+	ATM              PoiCategory = "ATM"              // An automated teller machine.
+	Aquarium         PoiCategory = "Aquarium"         // An aquarium.
+	AutomotiveRepair PoiCategory = "AutomotiveRepair" // An automotive repair shop.
+	Bakery           PoiCategory = "Bakery"           // A bakery.
+	Bank             PoiCategory = "Bank"             // A bank.
+	Baseball         PoiCategory = "Baseball"         // A baseball field or stadium.
+	Basketball       PoiCategory = "Basketball"       // A basketball court or stadium.
+	Beach            PoiCategory = "Beach"            // A beach.
+	Beauty           PoiCategory = "Beauty"           // A beauty salon or barbershop.
+	Bowling          PoiCategory = "Bowling"          // A bowling alley.
+	Brewery          PoiCategory = "Brewery"          // A brewery.
+	Cafe             PoiCategory = "Cafe"             // A cafe.
+	Campground       PoiCategory = "Campground"       // A campground.
+	CarRental        PoiCategory = "CarRental"        // A car rental location.
+	Castle           PoiCategory = "Castle"           // A castle.
+	ConventionCenter PoiCategory = "ConventionCenter" // A convention center.
+	Distillery       PoiCategory = "Distillery"       // A distillery.
+	EVCharger        PoiCategory = "EVCharger"        // An electric vehicle (EV) charger.
+	Fairground       PoiCategory = "Fairground"       // A fairground.
+	FireStation      PoiCategory = "FireStation"      // A fire station.
+	Fishing          PoiCategory = "Fishing"          // A fishing location.
+	FitnessCenter    PoiCategory = "FitnessCenter"    // A fitness center.
+	FoodMarket       PoiCategory = "FoodMarket"       // A food market.
+	Fortress         PoiCategory = "Fortress"         // A fortress.
+	GasStation       PoiCategory = "GasStation"       // A gas station.
+	GoKart           PoiCategory = "GoKart"           // A go-kart track.
+	Golf             PoiCategory = "Golf"             // A golf course.
+	Hiking           PoiCategory = "Hiking"           // A hiking trail.
+	Hospital         PoiCategory = "Hospital"         // A hospital.
+	Hotel            PoiCategory = "Hotel"            // A hotel.
+	Kayaking         PoiCategory = "Kayaking"         // A kayaking location.
+	Landmark         PoiCategory = "Landmark"         // A landmark.
+	Laundry          PoiCategory = "Laundry"          // A laundry.
+	Library          PoiCategory = "Library"          // A library.
+	Marina           PoiCategory = "Marina"           // A marina.
+	MiniGolf         PoiCategory = "MiniGolf"         // A miniature golf course.
+	MovieTheater     PoiCategory = "MovieTheater"     // A movie theater.
+	Museum           PoiCategory = "Museum"           // A museum.
+	MusicVenue       PoiCategory = "MusicVenue"       // A music venue.
+	NationalMonument PoiCategory = "NationalMonument" // A national monument.
+	NationalPark     PoiCategory = "NationalPark"     // A national park.
+	Nightlife        PoiCategory = "Nightlife"        // A night life venue.
+	Park             PoiCategory = "Park"             // A park.
+	Parking          PoiCategory = "Parking"          // A parking location for an automobile.
+	Pharmacy         PoiCategory = "Pharmacy"         // A pharmacy.
+	Planetarium      PoiCategory = "Planetarium"      // A planetarium.
+	Playground       PoiCategory = "Playground"       // A playground.
+	Police           PoiCategory = "Police"           // A police station.
+	PostOffice       PoiCategory = "PostOffice"       // A post office.
+	PublicTransport  PoiCategory = "PublicTransport"  // A public transportation station.
+	RVPark           PoiCategory = "RVPark"           // A park for recreational vehicles.
+	ReligiousSite    PoiCategory = "ReligiousSite"    // A religious site.
+	Restaurant       PoiCategory = "Restaurant"       // A restaurant.
+	Restroom         PoiCategory = "Restroom"         // A restroom.
+	RockClimbing     PoiCategory = "RockClimbing"     // A rock climbing location.
+	School           PoiCategory = "School"           // A school.
+	SkatePark        PoiCategory = "SkatePark"        // A skate park.
+	Skating          PoiCategory = "Skating"          // A skating rink.
+	Skiing           PoiCategory = "Skiing"           // A ski resort.
+	Soccer           PoiCategory = "Soccer"           // A soccer field or stadium.
+	Spa              PoiCategory = "Spa"              // A spa.
+	Stadium          PoiCategory = "Stadium"          // A stadium.
+	Store            PoiCategory = "Store"            // A store.
+	Surfing          PoiCategory = "Surfing"          // A surfing location.
+	Swimming         PoiCategory = "Swimming"         // A swimming pool or location.
+	Tennis           PoiCategory = "Tennis"           // A tennis court.
+	Theater          PoiCategory = "Theater"          // A theater.
+	University       PoiCategory = "University"       // A university.
+	Volleyball       PoiCategory = "Volleyball"       // A volleyball court.
+	Winery           PoiCategory = "Winery"           // A winery.
+	Zoo              PoiCategory = "Zoo"              // A zoo.
+)
+
+var allPoiCategories = []PoiCategory{
+	Airport,
+	AirportGate,
+	AirportTerminal,
+	AmusementPark,
+	AnimalService,
+	ATM,
+	Aquarium,
+	AutomotiveRepair,
+	Bakery,
+	Bank,
+	Baseball,
+	Basketball,
+	Beach,
+	Beauty,
+	Bowling,
+	Brewery,
+	Cafe,
+	Campground,
+	CarRental,
+	Castle,
+	ConventionCenter,
+	Distillery,
+	EVCharger,
+	Fairground,
+	FireStation,
+	Fishing,
+	FitnessCenter,
+	FoodMarket,
+	Fortress,
+	GasStation,
+	GoKart,
+	Golf,
+	Hiking,
+	Hospital,
+	Hotel,
+	Kayaking,
+	Landmark,
+	Laundry,
+	Library,
+	Marina,
+	MiniGolf,
+	MovieTheater,
+	Museum,
+	MusicVenue,
+	NationalMonument,
+	NationalPark,
+	Nightlife,
+	Park,
+	Parking,
+	Pharmacy,
+	Planetarium,
+	Playground,
+	Police,
+	PostOffice,
+	PublicTransport,
+	RVPark,
+	ReligiousSite,
+	Restaurant,
+	Restroom,
+	RockClimbing,
+	School,
+	SkatePark,
+	Skating,
+	Skiing,
+	Soccer,
+	Spa,
+	Stadium,
+	Store,
+	Surfing,
+	Swimming,
+	Tennis,
+	Theater,
+	University,
+	Volleyball,
+	Winery,
+	Zoo,
+}
@@ -0,0 +1,85 @@
+package am_test
+
+import (
+	"testing"
+
+	am "github.com/ringsaturn/am"
+)
+
+func TestParsePoiCategory(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    am.PoiCategory
+		wantErr bool
+	}{
+		{input: "Cafe", want: am.Cafe},
+		{input: "cafe", want: am.Cafe},
+		{input: " EVCHARGER ", want: am.EVCharger},
+		{input: "rvpark", want: am.RVPark},
+		{input: "Cafee", wantErr: true},
+		{input: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := am.ParsePoiCategory(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePoiCategory(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParsePoiCategory(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllPoiCategories(t *testing.T) {
+	all := am.AllPoiCategories()
+	if len(all) == 0 {
+		t.Fatal("AllPoiCategories() is empty")
+	}
+	seen := map[am.PoiCategory]bool{}
+	for _, poi := range all {
+		if seen[poi] {
+			t.Errorf("duplicated category %q", poi)
+		}
+		seen[poi] = true
+		if !poi.IsValid() {
+			t.Errorf("%q.IsValid() = false", poi)
+		}
+	}
+	// Callers must not be able to modify the catalog.
+	all[0] = "Modified"
+	if am.AllPoiCategories()[0] == "Modified" {
+		t.Error("AllPoiCategories() returns the internal slice")
+	}
+	if am.PoiCategory("cafe").IsValid() {
+		t.Error(`PoiCategory("cafe").IsValid() = true, want false`)
+	}
+}
+
+func TestSearchRequest_ValidatePoiCategories(t *testing.T) {
+	tests := []struct {
+		name    string
+		include []am.PoiCategory
+		exclude []am.PoiCategory
+		wantErr bool
+	}{
+		{name: "ok", include: []am.PoiCategory{am.Cafe}, exclude: []am.PoiCategory{am.Restaurant}},
+		{name: "unknown include", include: []am.PoiCategory{"Cafee"}, wantErr: true},
+		{name: "unknown exclude", exclude: []am.PoiCategory{"cafe"}, wantErr: true},
+		{name: "conflict", include: []am.PoiCategory{am.Cafe, am.Bakery}, exclude: []am.PoiCategory{am.Bakery}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			search := &am.SearchRequest{Query: "coffee", IncludePoiCategories: tt.include, ExcludePoiCategories: tt.exclude}
+			if err := search.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("SearchRequest.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			autocomplete := &am.SearchAutoCompleteRequest{Query: "coffee", IncludePoiCategories: tt.include, ExcludePoiCategories: tt.exclude}
+			if err := autocomplete.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("SearchAutoCompleteRequest.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package am
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ErrInvalidPolyline is wrapped by the errors of DecodePolyline when the
+// string is not an encoded polyline.
+var ErrInvalidPolyline = errors.New("am: invalid encoded polyline")
+
+func polylineFactor(precision int) (float64, error) {
+	switch precision {
+	case 5:
+		return 1e5, nil
+	case 6:
+		return 1e6, nil
+	}
+	return 0, fmt.Errorf("am: unsupported polyline precision %d, want 5 or 6", precision)
+}
+
+func encodePolylineValue(sb *strings.Builder, v int64) {
+	u := uint64(v) << 1
+	if v < 0 {
+		u = ^u
+	}
+	for u >= 0x20 {
+		sb.WriteByte(byte((0x20 | (u & 0x1f)) + 63))
+		u >>= 5
+	}
+	sb.WriteByte(byte(u + 63))
+}
+
+// EncodePolyline encodes the points with the Encoded Polyline Algorithm
+// Format, precision is the number of decimal digits kept, 5 like the Google
+// Maps APIs or 6 like OSRM and Valhalla.
+//
+// A decoded point is within 0.5*10^-precision degree of the original one.
+func EncodePolyline(locs []Location, precision int) (string, error) {
+	factor, err := polylineFactor(precision)
+	if err != nil {
+		return "", err
+	}
+	sb := &strings.Builder{}
+	var prevLat, prevLon int64
+	for i, loc := range locs {
+		if math.IsNaN(loc.Latitude) || math.IsInf(loc.Latitude, 0) ||
+			math.IsNaN(loc.Longitude) || math.IsInf(loc.Longitude, 0) {
+			return "", fmt.Errorf("am: can't encode point %d %v", i, loc)
+		}
+		lat := int64(math.Round(loc.Latitude * factor))
+		lon := int64(math.Round(loc.Longitude * factor))
+		encodePolylineValue(sb, lat-prevLat)
+		encodePolylineValue(sb, lon-prevLon)
+		prevLat, prevLon = lat, lon
+	}
+	return sb.String(), nil
+}
+
+// decodePolylineValue decodes the value starting at s[i], returns it with
+// the index of the next one.
+func decodePolylineValue(s string, i int) (int64, int, error) {
+	var (
+		u     uint64
+		shift uint
+	)
+	for {
+		if i >= len(s) {
+			return 0, i, fmt.Errorf("%w: truncated at %d", ErrInvalidPolyline, i)
+		}
+		c := s[i]
+		if c < 63 || c > 126 {
+			return 0, i, fmt.Errorf("%w: invalid character %q at %d", ErrInvalidPolyline, c, i)
+		}
+		b := uint64(c - 63)
+		// The chunk at shift 60 only has room for 4 bits.
+		if shift >= 64 || shift > 64-5 && (b&0x1f)>>(64-shift) != 0 {
+			return 0, i, fmt.Errorf("%w: value overflows at %d", ErrInvalidPolyline, i)
+		}
+		u |= (b & 0x1f) << shift
+		shift += 5
+		i++
+		if b < 0x20 {
+			break
+		}
+	}
+	v := int64(u >> 1)
+	if u&1 != 0 {
+		v = ^v
+	}
+	return v, i, nil
+}
+
+// DecodePolyline decodes a string encoded by EncodePolyline with the same
+// precision.
+func DecodePolyline(s string, precision int) ([]Location, error) {
+	factor, err := polylineFactor(precision)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		locs     []Location
+		lat, lon int64
+	)
+	for i := 0; i < len(s); {
+		var dLat, dLon int64
+		if dLat, i, err = decodePolylineValue(s, i); err != nil {
+			return nil, err
+		}
+		if dLon, i, err = decodePolylineValue(s, i); err != nil {
+			return nil, err
+		}
+		lat += dLat
+		lon += dLon
+		locs = append(locs, Location{Latitude: float64(lat) / factor, Longitude: float64(lon) / factor})
+	}
+	return locs, nil
+}
+
+// EncodedPolyline returns the Polyline of the route encoded by
+// EncodePolyline, which is much smaller than the points as JSON.
+func (r *DirectionsRoute) EncodedPolyline(precision int) (string, error) {
+	polyline, err := r.Polyline()
+	if err != nil {
+		return "", err
+	}
+	return EncodePolyline(polyline, precision)
+}
@@ -0,0 +1,124 @@
+package am_test
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodePolyline(t *testing.T) {
+	// The example of the Encoded Polyline Algorithm Format documentation.
+	locs := []am.Location{
+		{Latitude: 38.5, Longitude: -120.2},
+		{Latitude: 40.7, Longitude: -120.95},
+		{Latitude: 43.252, Longitude: -126.453},
+	}
+	got, err := am.EncodePolyline(locs, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "_p~iF~ps|U_ulLnnqC_mqNvxq`@", got)
+
+	decoded, err := am.DecodePolyline(got, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, locs, decoded)
+
+	got, err = am.EncodePolyline(locs, 6)
+	assert.NoError(t, err)
+	decoded, err = am.DecodePolyline(got, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, locs, decoded)
+
+	got, err = am.EncodePolyline(nil, 5)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestPolyline_Invalid(t *testing.T) {
+	_, err := am.EncodePolyline(nil, 7)
+	assert.Error(t, err)
+	_, err = am.DecodePolyline("", 4)
+	assert.Error(t, err)
+	_, err = am.EncodePolyline([]am.Location{{Latitude: math.NaN()}}, 5)
+	assert.Error(t, err)
+
+	for _, s := range []string{
+		"_p~iF",           // latitude without longitude
+		"_p~iF~ps|",       // truncated value
+		"_p~iF ps|U",      // invalid character
+		"~~~~~~~~~~~~~~~", // overflows
+		"~~~~~~~~~~~~O?",  // loses the high bit of the 13th chunk
+		"_p~iF\x7f?",      // DEL is out of the alphabet
+	} {
+		_, err := am.DecodePolyline(s, 5)
+		assert.ErrorIs(t, err, am.ErrInvalidPolyline, s)
+	}
+}
+
+type polylineInput []am.Location
+
+func (polylineInput) Generate(r *rand.Rand, size int) reflect.Value {
+	locs := make(polylineInput, r.Intn(size+1))
+	for i := range locs {
+		locs[i] = am.Location{
+			Latitude:  r.Float64()*180 - 90,
+			Longitude: r.Float64()*360 - 180,
+		}
+	}
+	return reflect.ValueOf(locs)
+}
+
+func TestPolyline_RoundTrip(t *testing.T) {
+	for _, precision := range []int{5, 6} {
+		bound := 0.5*math.Pow10(-precision) + 1e-12
+		f := func(locs polylineInput) bool {
+			s, err := am.EncodePolyline(locs, precision)
+			if err != nil {
+				return false
+			}
+			decoded, err := am.DecodePolyline(s, precision)
+			if err != nil || len(decoded) != len(locs) {
+				return false
+			}
+			for i := range locs {
+				if math.Abs(decoded[i].Latitude-locs[i].Latitude) > bound ||
+					math.Abs(decoded[i].Longitude-locs[i].Longitude) > bound {
+					return false
+				}
+			}
+			// Decoded points are on the grid, so they survive another round.
+			again, err := am.EncodePolyline(decoded, precision)
+			return err == nil && again == s
+		}
+		if err := quick.Check(f, &quick.Config{MaxCount: 500}); err != nil {
+			t.Errorf("precision %d: %v", precision, err)
+		}
+	}
+}
+
+func TestDirectionsRoute_EncodedPolyline(t *testing.T) {
+	resp := loadDirectionsResponse(t)
+	route := resp.Route(0)
+	polyline, _ := route.Polyline()
+
+	s, err := route.EncodedPolyline(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := am.DecodePolyline(s, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, decoded, len(polyline))
+	for i := range polyline {
+		assert.InDelta(t, polyline[i].Latitude, decoded[i].Latitude, 0.5e-6+1e-12)
+		assert.InDelta(t, polyline[i].Longitude, decoded[i].Longitude, 0.5e-6+1e-12)
+	}
+
+	raw, _ := json.Marshal(polyline)
+	assert.Less(t, len(s)*4, len(raw))
+}
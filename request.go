@@ -2,6 +2,7 @@ package am
 
 import (
 	"errors"
+	"fmt"
 	"net/url"
 	"strings"
 	"time"
@@ -19,14 +20,18 @@ var (
 	_ query = (*SearchAutoCompleteRequest)(nil)
 	_ query = (*DirectionsRequest)(nil)
 	_ query = (*EtaRequest)(nil)
+	_ query = (*PlaceRequest)(nil)
+	_ query = (*PlacesRequest)(nil)
+	_ query = (*AlternateIDsRequest)(nil)
+	_ query = (*completionRequest)(nil)
 )
 
-func poiCategoriesToString(pois []PoiCategory) string {
-	items := []string{}
-	for _, poi := range pois {
-		items = append(items, string(poi))
+func joinStrings[T ~string](items []T) string {
+	strs := make([]string, 0, len(items))
+	for _, item := range items {
+		strs = append(strs, string(item))
 	}
-	return strings.Join(items, ",")
+	return strings.Join(strs, ",")
 }
 
 func countriesToString(countries []countries.CountryCode) string {
@@ -37,12 +42,34 @@ func countriesToString(countries []countries.CountryCode) string {
 	return strings.Join(items, ",")
 }
 
-func avoidToString(avoid []DirectionsAvoid) string {
-	items := []string{}
-	for _, a := range avoid {
-		items = append(items, string(a))
+// validateIncludeExclude checks every item is known and none is both included
+// and excluded. param is the query parameter suffix, e.g. "PoiCategories".
+func validateIncludeExclude[T ~string](kind, param string, include, exclude []T, valid func(T) bool) error {
+	included := make(map[T]bool, len(include))
+	for _, item := range include {
+		if !valid(item) {
+			return fmt.Errorf("am: unknown %s %q in include%s", kind, item, param)
+		}
+		included[item] = true
 	}
-	return strings.Join(items, ",")
+	for _, item := range exclude {
+		if !valid(item) {
+			return fmt.Errorf("am: unknown %s %q in exclude%s", kind, item, param)
+		}
+		if included[item] {
+			return fmt.Errorf("am: %s %q is both included and excluded", kind, item)
+		}
+	}
+	return nil
+}
+
+func validateResultTypes(filter []ResultType, allowed map[ResultType]bool) error {
+	for _, t := range filter {
+		if !allowed[t] {
+			return fmt.Errorf("am: result type %q is not allowed in resultTypeFilter", t)
+		}
+	}
+	return nil
 }
 
 // https://developer.apple.com/documentation/applemapsserverapi/geocode_an_address
@@ -67,13 +94,13 @@ type GeocodeRequest struct {
 	// A location defined by the application as a hint. Specify the location as
 	// a comma-separated string containing the latitude and longitude.
 	// For example, searchLocation=37.78,-122.42.
-	SearchLocation Location `query:"searchLocation,omitempty"`
+	SearchLocation *Location `query:"searchLocation,omitempty"`
 
 	// A region the app defines as a hint. Specify the region specified as a
 	// comma-separated string that describes the region in the form
 	// north-latitude, east-longitude, south-latitude, west-longitude.
 	// For example, searchRegion=38,-122.1,37.5,-122.5.
-	SearchRegion Region `query:"searchRegion,omitempty"`
+	SearchRegion *Region `query:"searchRegion,omitempty"`
 
 	// The location of the user, specified as a comma-separated string that
 	// contains the latitude and longitude.
@@ -81,7 +108,7 @@ type GeocodeRequest struct {
 	//
 	// Certain APIs, such as Searching, may opt to
 	// use the userLocation, if specified, as a fallback for the searchLocation.
-	UserLocation Location `query:"userLocation,omitempty"`
+	UserLocation *Location `query:"userLocation,omitempty"`
 }
 
 func (req *GeocodeRequest) Validate() error { return vd.Validate(req) }
@@ -101,13 +128,13 @@ func (req *GeocodeRequest) URLValues() (url.Values, error) {
 	if !req.Lang.IsRoot() {
 		q.Set("lang", req.Lang.String())
 	}
-	if !req.SearchLocation.IsEmpty() {
+	if req.SearchLocation != nil {
 		q.Set("searchLocation", req.SearchLocation.QueryString())
 	}
-	if !req.SearchRegion.IsEmpty() {
+	if req.SearchRegion != nil {
 		q.Set("searchRegion", req.SearchRegion.QueryString())
 	}
-	if !req.UserLocation.IsEmpty() {
+	if req.UserLocation != nil {
 		q.Set("userLocation", req.UserLocation.QueryString())
 	}
 	return q, nil
@@ -116,7 +143,9 @@ func (req *GeocodeRequest) URLValues() (url.Values, error) {
 type ReverseRequest struct {
 	// (Required) The coordinate to reverse geocode as a comma-separated string
 	// that contains the latitude and longitude. For example: loc=37.3316851,-122.0300674.
-	Loc Location `query:"loc"`
+	//
+	// A nil Loc is rejected, 0,0 is a valid coordinate.
+	Loc *Location `query:"loc"`
 
 	// The language the server uses when returning the response, specified using
 	// a BCP 47 language code. For example, for English, use lang=en-US.
@@ -125,7 +154,7 @@ type ReverseRequest struct {
 }
 
 func (req *ReverseRequest) Validate() error {
-	if req.Loc.IsEmpty() {
+	if req.Loc == nil {
 		return errors.New("am: loc is required")
 	}
 	return vd.Validate(req)
@@ -174,8 +203,22 @@ type SearchRequest struct {
 	// A comma-separated list of strings that describes the kind of result types
 	// to include in the response. For example, resultTypeFilter=Poi.
 	//
-	// Possible Values: Poi, Address
-	ResultTypeFilter []string `query:"resultTypeFilter"`
+	// Possible Values: Address, Poi, PhysicalFeature
+	ResultTypeFilter []ResultType `query:"resultTypeFilter"`
+
+	// A comma-separated list of strings that describes the address categories
+	// to include in the search results.
+	// For example, includeAddressCategories=Locality,PostalCode.
+	//
+	// See AddressCategory for a complete list of possible values.
+	IncludeAddressCategories []AddressCategory `query:"includeAddressCategories"`
+
+	// A comma-separated list of strings that describes the address categories
+	// to exclude from the search results.
+	// For example, excludeAddressCategories=Country.
+	//
+	// See AddressCategory for a complete list of possible values.
+	ExcludeAddressCategories []AddressCategory `query:"excludeAddressCategories"`
 
 	// The language the server should use when returning the response, specified
 	// using a BCP 47 language code.
@@ -187,23 +230,43 @@ type SearchRequest struct {
 	// A location defined by the application as a hint. Specify the location as
 	// a comma-separated string containing the latitude and longitude.
 	// For example, searchLocation=37.78,-122.42.
-	SearchLocation Location `query:"searchLocation"`
+	SearchLocation *Location `query:"searchLocation"`
 
 	// A region the app defines as a hint. Specify the region specified as a
 	// comma-separated string that describes the region in the form
 	// north-latitude,east-longitude,south-latitude,west-longitude.
 	// For example, searchRegion=38,-122.1,37.5,-122.5.
-	SearchRegion Region `query:"searchRegion"`
+	SearchRegion *Region `query:"searchRegion"`
 
 	// The location of the user, specified as a comma-separated string that
 	// contains the latitude and longitude.
 	// For example, userLocation=37.78,-122.42.
 	// Search may opt to use the userLocation, if specified, as a fallback for
 	// the searchLocation.
-	UserLocation Location `query:"userLocation"`
+	UserLocation *Location `query:"userLocation"`
+
+	// When true, the server returns results in pages, use PageToken to get the
+	// following ones. For example, enablePagination=true.
+	// Default: false
+	EnablePagination bool `query:"enablePagination"`
+
+	// The token of the page to fetch, from SearchResponse.NextPageToken of the
+	// previous page. For example, pageToken=ABC.
+	PageToken string `query:"pageToken"`
 }
 
-func (req *SearchRequest) Validate() error { return vd.Validate(req) }
+func (req *SearchRequest) Validate() error {
+	if err := validatePoiCategories(req.IncludePoiCategories, req.ExcludePoiCategories); err != nil {
+		return err
+	}
+	if err := validateResultTypes(req.ResultTypeFilter, searchResultTypes); err != nil {
+		return err
+	}
+	if err := validateIncludeExclude("address category", "AddressCategories", req.IncludeAddressCategories, req.ExcludeAddressCategories, AddressCategory.IsValid); err != nil {
+		return err
+	}
+	return vd.Validate(req)
+}
 
 func (req *SearchRequest) URLValues() (url.Values, error) {
 	if err := req.Validate(); err != nil {
@@ -212,29 +275,41 @@ func (req *SearchRequest) URLValues() (url.Values, error) {
 	q := make(url.Values)
 	q.Add("q", req.Query)
 	if len(req.ExcludePoiCategories) > 0 {
-		q.Add("excludePoiCategories", poiCategoriesToString(req.ExcludePoiCategories))
+		q.Add("excludePoiCategories", joinStrings(req.ExcludePoiCategories))
 	}
 	if len(req.IncludePoiCategories) > 0 {
-		q.Add("includePoiCategories", poiCategoriesToString(req.IncludePoiCategories))
+		q.Add("includePoiCategories", joinStrings(req.IncludePoiCategories))
 	}
 	if len(req.LimitToCountries) > 0 {
 		q.Add("limitToCountries", countriesToString(req.LimitToCountries))
 	}
 	if len(req.ResultTypeFilter) > 0 {
-		q.Add("resultTypeFilter", strings.Join(req.ResultTypeFilter, ","))
+		q.Add("resultTypeFilter", joinStrings(req.ResultTypeFilter))
+	}
+	if len(req.IncludeAddressCategories) > 0 {
+		q.Add("includeAddressCategories", joinStrings(req.IncludeAddressCategories))
+	}
+	if len(req.ExcludeAddressCategories) > 0 {
+		q.Add("excludeAddressCategories", joinStrings(req.ExcludeAddressCategories))
 	}
 	if !req.Lang.IsRoot() {
 		q.Set("lang", req.Lang.String())
 	}
-	if !req.SearchLocation.IsEmpty() {
+	if req.SearchLocation != nil {
 		q.Set("searchLocation", req.SearchLocation.QueryString())
 	}
-	if !req.SearchRegion.IsEmpty() {
+	if req.SearchRegion != nil {
 		q.Set("searchRegion", req.SearchRegion.QueryString())
 	}
-	if !req.UserLocation.IsEmpty() {
+	if req.UserLocation != nil {
 		q.Set("userLocation", req.UserLocation.QueryString())
 	}
+	if req.EnablePagination {
+		q.Set("enablePagination", "true")
+	}
+	if req.PageToken != "" {
+		q.Set("pageToken", req.PageToken)
+	}
 	return q, nil
 }
 
@@ -269,8 +344,22 @@ type SearchAutoCompleteRequest struct {
 	// A comma-separated list of strings that describes the kind of result types
 	// to include in the response. For example, resultTypeFilter=Poi.
 	//
-	// Possible Values: Address, Poi, Query
-	ResultTypeFilter []string `query:"resultTypeFilter"`
+	// Possible Values: Address, Poi, PhysicalFeature, Query
+	ResultTypeFilter []ResultType `query:"resultTypeFilter"`
+
+	// A comma-separated list of strings that describes the address categories
+	// to include in the autocomplete results.
+	// For example, includeAddressCategories=Locality,PostalCode.
+	//
+	// See AddressCategory for a complete list of possible values.
+	IncludeAddressCategories []AddressCategory `query:"includeAddressCategories"`
+
+	// A comma-separated list of strings that describes the address categories
+	// to exclude from the autocomplete results.
+	// For example, excludeAddressCategories=Country.
+	//
+	// See AddressCategory for a complete list of possible values.
+	ExcludeAddressCategories []AddressCategory `query:"excludeAddressCategories"`
 
 	// The language the server should use when returning the response, specified
 	// using a BCP 47 language code.
@@ -282,23 +371,34 @@ type SearchAutoCompleteRequest struct {
 	// A location defined by the application as a hint. Specify the location as
 	// a comma-separated string containing the latitude and longitude.
 	// For example, searchLocation=37.78,-122.42.
-	SearchLocation Location `query:"searchLocation"`
+	SearchLocation *Location `query:"searchLocation"`
 
 	// A region the app defines as a hint. Specify the region specified as a
 	// comma-separated string that describes the region in the form
 	// north-latitude,east-longitude,south-latitude,west-longitude.
 	// For example, searchRegion=38,-122.1,37.5,-122.5.
-	SearchRegion Region `query:"searchRegion"`
+	SearchRegion *Region `query:"searchRegion"`
 
 	// The location of the user, specified as a comma-separated string that
 	// contains the latitude and longitude.
 	// For example, userLocation=37.78,-122.42.
 	// Search may opt to use the userLocation, if specified, as a fallback for
 	// the searchLocation.
-	UserLocation Location `query:"userLocation"`
+	UserLocation *Location `query:"userLocation"`
 }
 
-func (req *SearchAutoCompleteRequest) Validate() error { return vd.Validate(req) }
+func (req *SearchAutoCompleteRequest) Validate() error {
+	if err := validatePoiCategories(req.IncludePoiCategories, req.ExcludePoiCategories); err != nil {
+		return err
+	}
+	if err := validateResultTypes(req.ResultTypeFilter, searchAutoCompleteResultTypes); err != nil {
+		return err
+	}
+	if err := validateIncludeExclude("address category", "AddressCategories", req.IncludeAddressCategories, req.ExcludeAddressCategories, AddressCategory.IsValid); err != nil {
+		return err
+	}
+	return vd.Validate(req)
+}
 
 func (req *SearchAutoCompleteRequest) URLValues() (url.Values, error) {
 	if err := req.Validate(); err != nil {
@@ -307,40 +407,88 @@ func (req *SearchAutoCompleteRequest) URLValues() (url.Values, error) {
 	q := make(url.Values)
 	q.Add("q", req.Query)
 	if len(req.ExcludePoiCategories) > 0 {
-		q.Add("excludePoiCategories", poiCategoriesToString(req.ExcludePoiCategories))
+		q.Add("excludePoiCategories", joinStrings(req.ExcludePoiCategories))
 	}
 	if len(req.IncludePoiCategories) > 0 {
-		q.Add("includePoiCategories", poiCategoriesToString(req.IncludePoiCategories))
+		q.Add("includePoiCategories", joinStrings(req.IncludePoiCategories))
 	}
 	if len(req.LimitToCountries) > 0 {
 		q.Add("limitToCountries", countriesToString(req.LimitToCountries))
 	}
 	if len(req.ResultTypeFilter) > 0 {
-		q.Add("resultTypeFilter", strings.Join(req.ResultTypeFilter, ","))
+		q.Add("resultTypeFilter", joinStrings(req.ResultTypeFilter))
+	}
+	if len(req.IncludeAddressCategories) > 0 {
+		q.Add("includeAddressCategories", joinStrings(req.IncludeAddressCategories))
+	}
+	if len(req.ExcludeAddressCategories) > 0 {
+		q.Add("excludeAddressCategories", joinStrings(req.ExcludeAddressCategories))
 	}
 	if !req.Lang.IsRoot() {
 		q.Set("lang", req.Lang.String())
 	}
-	if !req.SearchLocation.IsEmpty() {
+	if req.SearchLocation != nil {
 		q.Set("searchLocation", req.SearchLocation.QueryString())
 	}
-	if !req.SearchRegion.IsEmpty() {
+	if req.SearchRegion != nil {
 		q.Set("searchRegion", req.SearchRegion.QueryString())
 	}
-	if !req.UserLocation.IsEmpty() {
+	if req.UserLocation != nil {
 		q.Set("userLocation", req.UserLocation.QueryString())
 	}
 	return q, nil
 }
 
+var searchURL = func() *url.URL {
+	u, err := url.Parse(V1_SEARCH)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}()
+
+// completionRequest replays the query of AutocompleteResult.CompletionURL,
+// keeping the opaque metadata parameter untouched.
+type completionRequest struct {
+	values url.Values
+}
+
+func newCompletionRequest(result *AutocompleteResult, lang language.Tag) (*completionRequest, error) {
+	if result == nil || result.CompletionURL == "" {
+		return nil, errors.New("am: completion url is required")
+	}
+	u, err := url.Parse(result.CompletionURL)
+	if err != nil {
+		return nil, fmt.Errorf("am: invalid completion url: %w", err)
+	}
+	// Never send the access token to other hosts.
+	if u.Host != "" && u.Host != searchURL.Host {
+		return nil, fmt.Errorf("am: completion url %q is not an Apple Maps Server API url", result.CompletionURL)
+	}
+	if u.Path != searchURL.Path {
+		return nil, fmt.Errorf("am: completion url %q is not a search url", result.CompletionURL)
+	}
+	values := u.Query()
+	if values.Get("q") == "" {
+		return nil, fmt.Errorf("am: completion url %q has no query", result.CompletionURL)
+	}
+	if !lang.IsRoot() {
+		values.Set("lang", lang.String())
+	}
+	return &completionRequest{values: values}, nil
+}
+
+func (req *completionRequest) URLValues() (url.Values, error) { return req.values, nil }
+
+// OneOfLoc is either an address or a coordinate. Address takes precedence
+// when both are set.
 type OneOfLoc struct {
 	Address  string
 	Location *Location
 }
 
 func (loc *OneOfLoc) IsEmpty() bool {
-	return loc.Address == "" &&
-		(loc.Location == nil || loc.Location.IsEmpty())
+	return loc.Address == "" && loc.Location == nil
 }
 
 func (loc *OneOfLoc) QueryString() string {
@@ -394,7 +542,7 @@ type DirectionsRequest struct {
 	// contains the latitude and longitude. For example, 37.7857,-122.4011.
 	// If you don’t provide a searchLocation, the server uses userLocation and
 	// searchLocation as fallback hints.
-	SearchLocation Location `query:"searchLocation"`
+	SearchLocation *Location `query:"searchLocation"`
 
 	// A region the app defines as a hint for the query input for origin or
 	// destination. Specify the region as a comma-separated string that
@@ -402,21 +550,26 @@ type DirectionsRequest struct {
 	// south-latitude, west-longitude string. For example, 38,-122.1,37.5,-122.5.
 	// If you don’t provide a searchLocation, the server uses userLocation and
 	// searchRegion as fallback hints.
-	SearchRegion Region `query:"searchRegion"`
+	SearchRegion *Region `query:"searchRegion"`
 
 	// The mode of transportation the server returns directions for.
 	// Default: Automobile
-	// Possible Values: Automobile, Walking
+	// Possible Values: Automobile, Walking, Cycling
 	TransportType TransportType `query:"transportType"`
 
 	// The location of the user, specified as a comma-separated string that
 	// contains the latitude and longitude. For example, userLocation=37.78,-122.42.
 	// If you don’t provide a searchLocation, the server uses userLocation and
 	// searchRegion as fallback hints.
-	UserLocation Location `query:"userLocation"`
+	UserLocation *Location `query:"userLocation"`
 }
 
-func (req *DirectionsRequest) Validate() error { return vd.Validate(req) }
+func (req *DirectionsRequest) Validate() error {
+	if err := validateTransportType(req.TransportType, directionsTransportTypes); err != nil {
+		return err
+	}
+	return vd.Validate(req)
+}
 
 func (req *DirectionsRequest) URLValues() (url.Values, error) {
 	if err := req.Validate(); err != nil {
@@ -435,7 +588,7 @@ func (req *DirectionsRequest) URLValues() (url.Values, error) {
 		q.Set("arrivalDate", req.ArrivalDate.UTC().Format(time.RFC3339))
 	}
 	if len(req.Avoid) > 0 {
-		q.Add("avoid", avoidToString(req.Avoid))
+		q.Add("avoid", joinStrings(req.Avoid))
 	}
 	if !req.DepartureDate.IsZero() {
 		q.Set("departureDate", req.DepartureDate.UTC().Format(time.RFC3339))
@@ -446,37 +599,40 @@ func (req *DirectionsRequest) URLValues() (url.Values, error) {
 	if req.RequestsAlternateRoutes {
 		q.Set("requestsAlternateRoutes", "true")
 	}
-	if !req.SearchLocation.IsEmpty() {
+	if req.SearchLocation != nil {
 		q.Set("searchLocation", req.SearchLocation.QueryString())
 	}
-	if !req.SearchRegion.IsEmpty() {
+	if req.SearchRegion != nil {
 		q.Set("searchRegion", req.SearchRegion.QueryString())
 	}
 	if req.TransportType != "" {
 		q.Set("transportType", string(req.TransportType))
 	}
-	if !req.UserLocation.IsEmpty() {
+	if req.UserLocation != nil {
 		q.Set("userLocation", req.UserLocation.QueryString())
 	}
 	return q, nil
 }
 
-// https://developer.apple.com/documentation/applemapsserverapi/determine_estimated_arrival_times_and_distances_to_one_or_more_destinations
-type EtasTransportType string
+// Deprecated: Use TransportType, ETA and directions share the same values.
+type EtasTransportType = TransportType
 
+// Deprecated: Use the TransportType constants.
 const (
-	EtasTransportTypeAutomobile EtasTransportType = "Automobile"
-	EtasTransportTypeWalking    EtasTransportType = "Walking"
-
-	// Ask Apple why this not in direction API
-	EtasTransportTypeTransit EtasTransportType = "Transit"
+	EtasTransportTypeAutomobile = TransportTypeAutomobile
+	EtasTransportTypeWalking    = TransportTypeWalking
+	EtasTransportTypeTransit    = TransportTypeTransit
 )
 
+// maxEtaDestinations is the maximum number of destinations of an EtaRequest,
+// use EtaMany for more.
+const maxEtaDestinations = 10
+
 type EtaRequest struct {
 	// (Required) The starting point for estimated arrival time requests,
 	// specified as a comma-separated string that contains the latitude and
 	// longitude. For example, origin=37.331423,-122.030503.
-	Origin Location `query:"origin"`
+	Origin *Location `query:"origin"`
 
 	// (Required) Destination coordinates represented as pairs of latitude and
 	// longitude separated by a vertical bar character (”|”).
@@ -488,8 +644,8 @@ type EtaRequest struct {
 
 	// The mode of transportation to use when estimating arrival times.
 	// Default: Automobile
-	// Possible Values: Automobile, Transit, Walking
-	TransportType EtasTransportType `query:"transportType"`
+	// Possible Values: Automobile, Transit, Walking, Cycling
+	TransportType TransportType `query:"transportType"`
 
 	// The time of departure to use in an estimated arrival time request, in ISO
 	// 8601 format in UTC time.
@@ -504,14 +660,17 @@ type EtaRequest struct {
 }
 
 func (req *EtaRequest) Validate() error {
-	if req.Origin.IsEmpty() {
+	if req.Origin == nil {
 		return errors.New("am: origin is required")
 	}
 	if len(req.Destinations) == 0 {
 		return errors.New("am: destinations is required")
 	}
-	if len(req.Destinations) > 10 {
-		return errors.New("am: destinations max length is 10")
+	if len(req.Destinations) > maxEtaDestinations {
+		return fmt.Errorf("am: destinations max length is %d", maxEtaDestinations)
+	}
+	if err := validateTransportType(req.TransportType, etaTransportTypes); err != nil {
+		return err
 	}
 	return vd.Validate(req)
 }
@@ -540,3 +699,94 @@ func (req *EtaRequest) URLValues() (url.Values, error) {
 	}
 	return q, nil
 }
+
+// https://developer.apple.com/documentation/applemapsserverapi/get_place_details
+type PlaceRequest struct {
+	// (Required) The identifier of the place, from Place.ID of a previous
+	// response. Sent as part of the path, for example /v1/place/I63802885C8189B2B.
+	ID string `vd:"$!=''"`
+
+	// The language the server uses when returning the response, specified using
+	// a BCP 47 language code. For example, for English, use lang=en-US.
+	// Default: en-US
+	Lang language.Tag `query:"lang"`
+}
+
+func (req *PlaceRequest) Validate() error { return vd.Validate(req) }
+
+func (req *PlaceRequest) URLValues() (url.Values, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	q := make(url.Values)
+	if !req.Lang.IsRoot() {
+		q.Set("lang", req.Lang.String())
+	}
+	return q, nil
+}
+
+func validatePlaceIDs(ids []string) error {
+	if len(ids) == 0 {
+		return errors.New("am: ids is required")
+	}
+	for _, id := range ids {
+		if id == "" {
+			return errors.New("am: ids contains empty id")
+		}
+	}
+	return nil
+}
+
+// https://developer.apple.com/documentation/applemapsserverapi/get_places_details
+type PlacesRequest struct {
+	// (Required) A comma-separated list of place identifiers.
+	// For example, ids=I63802885C8189B2B,ICD5F9ABE8FB2E1B5.
+	IDs []string `query:"ids"`
+
+	// The language the server uses when returning the response, specified using
+	// a BCP 47 language code. For example, for English, use lang=en-US.
+	// Default: en-US
+	Lang language.Tag `query:"lang"`
+}
+
+func (req *PlacesRequest) Validate() error {
+	if err := validatePlaceIDs(req.IDs); err != nil {
+		return err
+	}
+	return vd.Validate(req)
+}
+
+func (req *PlacesRequest) URLValues() (url.Values, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	q := make(url.Values)
+	q.Set("ids", strings.Join(req.IDs, ","))
+	if !req.Lang.IsRoot() {
+		q.Set("lang", req.Lang.String())
+	}
+	return q, nil
+}
+
+// https://developer.apple.com/documentation/applemapsserverapi/get_place_alternate_ids
+type AlternateIDsRequest struct {
+	// (Required) A comma-separated list of place identifiers to look up
+	// alternate identifiers for. For example, ids=I63802885C8189B2B.
+	IDs []string `query:"ids"`
+}
+
+func (req *AlternateIDsRequest) Validate() error {
+	if err := validatePlaceIDs(req.IDs); err != nil {
+		return err
+	}
+	return vd.Validate(req)
+}
+
+func (req *AlternateIDsRequest) URLValues() (url.Values, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	q := make(url.Values)
+	q.Set("ids", strings.Join(req.IDs, ","))
+	return q, nil
+}
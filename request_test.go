@@ -16,9 +16,9 @@ func TestGeocodeRequest_URLValues(t *testing.T) {
 		Query            string
 		LimitToCountries []countries.CountryCode
 		Lang             language.Tag
-		SearchLocation   am.Location
-		SearchRegion     am.Region
-		UserLocation     am.Location
+		SearchLocation   *am.Location
+		SearchRegion     *am.Region
+		UserLocation     *am.Location
 	}
 	tests := []struct {
 		name    string
@@ -74,17 +74,17 @@ func TestGeocodeRequest_URLValues(t *testing.T) {
 					countries.Japan,
 				},
 				Lang: language.AmericanEnglish,
-				SearchLocation: am.Location{
+				SearchLocation: &am.Location{
 					Latitude:  34.985849,
 					Longitude: 135.7561864,
 				},
-				SearchRegion: am.Region{
+				SearchRegion: &am.Region{
 					NorthLatitude: 35.0219,
 					EastLongitude: 135.8426,
 					SouthLatitude: 34.8440,
 					WestLongitude: 135.6215,
 				},
-				UserLocation: am.Location{
+				UserLocation: &am.Location{
 					Latitude:  34.985849,
 					Longitude: 135.7561864,
 				},
@@ -107,6 +107,29 @@ func TestGeocodeRequest_URLValues(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Gulf of Guinea",
+			fields: fields{
+				Query:          "hello",
+				SearchLocation: am.NewLocation(0, 0),
+				SearchRegion:   &am.Region{},
+			},
+			want: url.Values{
+				"q":              []string{"hello"},
+				"searchLocation": []string{"0,0"},
+				"searchRegion":   []string{"0,0,0,0"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid latitude",
+			fields: fields{
+				Query:          "hello",
+				SearchLocation: am.NewLocation(91, 0),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -132,7 +155,7 @@ func TestGeocodeRequest_URLValues(t *testing.T) {
 
 func TestReverseRequest_URLValues(t *testing.T) {
 	type fields struct {
-		Loc  am.Location
+		Loc  *am.Location
 		Lang language.Tag
 	}
 	tests := []struct {
@@ -144,7 +167,7 @@ func TestReverseRequest_URLValues(t *testing.T) {
 		{
 			name: "hello",
 			fields: fields{
-				Loc: am.Location{
+				Loc: &am.Location{
 					Latitude:  37.33182,
 					Longitude: -122.03118,
 				},
@@ -159,7 +182,7 @@ func TestReverseRequest_URLValues(t *testing.T) {
 		{
 			name: "no lang",
 			fields: fields{
-				Loc: am.Location{
+				Loc: &am.Location{
 					Latitude:  37.33182,
 					Longitude: -122.03118,
 				},
@@ -177,6 +200,16 @@ func TestReverseRequest_URLValues(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "null island",
+			fields: fields{
+				Loc: am.NewLocation(0, 0),
+			},
+			want: url.Values{
+				"loc": []string{"0,0"},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -198,15 +231,19 @@ func TestReverseRequest_URLValues(t *testing.T) {
 
 func TestSearchRequest_URLValues(t *testing.T) {
 	type fields struct {
-		Query                string
-		ExcludePoiCategories []am.PoiCategory
-		IncludePoiCategories []am.PoiCategory
-		LimitToCountries     []countries.CountryCode
-		ResultTypeFilter     []string
-		Lang                 language.Tag
-		SearchLocation       am.Location
-		SearchRegion         am.Region
-		UserLocation         am.Location
+		Query                    string
+		ExcludePoiCategories     []am.PoiCategory
+		IncludePoiCategories     []am.PoiCategory
+		LimitToCountries         []countries.CountryCode
+		ResultTypeFilter         []am.ResultType
+		IncludeAddressCategories []am.AddressCategory
+		ExcludeAddressCategories []am.AddressCategory
+		EnablePagination         bool
+		PageToken                string
+		Lang                     language.Tag
+		SearchLocation           *am.Location
+		SearchRegion             *am.Region
+		UserLocation             *am.Location
 	}
 	tests := []struct {
 		name    string
@@ -262,17 +299,17 @@ func TestSearchRequest_URLValues(t *testing.T) {
 					countries.Japan,
 				},
 				Lang: language.AmericanEnglish,
-				SearchLocation: am.Location{
+				SearchLocation: &am.Location{
 					Latitude:  34.985849,
 					Longitude: 135.7561864,
 				},
-				SearchRegion: am.Region{
+				SearchRegion: &am.Region{
 					NorthLatitude: 35.0219,
 					EastLongitude: 135.8426,
 					SouthLatitude: 34.8440,
 					WestLongitude: 135.6215,
 				},
-				UserLocation: am.Location{
+				UserLocation: &am.Location{
 					Latitude:  34.985849,
 					Longitude: 135.7561864,
 				},
@@ -295,19 +332,81 @@ func TestSearchRequest_URLValues(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "address categories",
+			fields: fields{
+				Query:                    "Cupertino",
+				ResultTypeFilter:         []am.ResultType{am.ResultTypeAddress, am.ResultTypePhysicalFeature},
+				IncludeAddressCategories: []am.AddressCategory{am.AddressCategoryLocality, am.AddressCategoryPostalCode},
+				ExcludeAddressCategories: []am.AddressCategory{am.AddressCategoryCountry},
+			},
+			want: url.Values{
+				"q":                        []string{"Cupertino"},
+				"resultTypeFilter":         []string{"Address,PhysicalFeature"},
+				"includeAddressCategories": []string{"Locality,PostalCode"},
+				"excludeAddressCategories": []string{"Country"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown address category",
+			fields: fields{
+				Query:                    "Cupertino",
+				IncludeAddressCategories: []am.AddressCategory{"City"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "conflict address category",
+			fields: fields{
+				Query:                    "Cupertino",
+				IncludeAddressCategories: []am.AddressCategory{am.AddressCategoryLocality},
+				ExcludeAddressCategories: []am.AddressCategory{am.AddressCategoryLocality},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "query result type",
+			fields: fields{
+				Query:            "Cupertino",
+				ResultTypeFilter: []am.ResultType{am.ResultTypeQuery},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "pagination",
+			fields: fields{
+				Query:            "coffee",
+				EnablePagination: true,
+				PageToken:        "ABC",
+			},
+			want: url.Values{
+				"q":                []string{"coffee"},
+				"enablePagination": []string{"true"},
+				"pageToken":        []string{"ABC"},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			req := &am.SearchRequest{
-				Query:                tt.fields.Query,
-				ExcludePoiCategories: tt.fields.ExcludePoiCategories,
-				IncludePoiCategories: tt.fields.IncludePoiCategories,
-				LimitToCountries:     tt.fields.LimitToCountries,
-				ResultTypeFilter:     tt.fields.ResultTypeFilter,
-				Lang:                 tt.fields.Lang,
-				SearchLocation:       tt.fields.SearchLocation,
-				SearchRegion:         tt.fields.SearchRegion,
-				UserLocation:         tt.fields.UserLocation,
+				Query:                    tt.fields.Query,
+				ExcludePoiCategories:     tt.fields.ExcludePoiCategories,
+				IncludePoiCategories:     tt.fields.IncludePoiCategories,
+				LimitToCountries:         tt.fields.LimitToCountries,
+				ResultTypeFilter:         tt.fields.ResultTypeFilter,
+				IncludeAddressCategories: tt.fields.IncludeAddressCategories,
+				ExcludeAddressCategories: tt.fields.ExcludeAddressCategories,
+				EnablePagination:         tt.fields.EnablePagination,
+				PageToken:                tt.fields.PageToken,
+				Lang:                     tt.fields.Lang,
+				SearchLocation:           tt.fields.SearchLocation,
+				SearchRegion:             tt.fields.SearchRegion,
+				UserLocation:             tt.fields.UserLocation,
 			}
 			got, err := req.URLValues()
 			if (err != nil) != tt.wantErr {
@@ -323,15 +422,17 @@ func TestSearchRequest_URLValues(t *testing.T) {
 
 func TestSearchAutoCompleteRequest_URLValues(t *testing.T) {
 	type fields struct {
-		Query                string
-		ExcludePoiCategories []am.PoiCategory
-		IncludePoiCategories []am.PoiCategory
-		LimitToCountries     []countries.CountryCode
-		ResultTypeFilter     []string
-		Lang                 language.Tag
-		SearchLocation       am.Location
-		SearchRegion         am.Region
-		UserLocation         am.Location
+		Query                    string
+		ExcludePoiCategories     []am.PoiCategory
+		IncludePoiCategories     []am.PoiCategory
+		LimitToCountries         []countries.CountryCode
+		ResultTypeFilter         []am.ResultType
+		IncludeAddressCategories []am.AddressCategory
+		ExcludeAddressCategories []am.AddressCategory
+		Lang                     language.Tag
+		SearchLocation           *am.Location
+		SearchRegion             *am.Region
+		UserLocation             *am.Location
 	}
 	tests := []struct {
 		name    string
@@ -387,17 +488,17 @@ func TestSearchAutoCompleteRequest_URLValues(t *testing.T) {
 					countries.Japan,
 				},
 				Lang: language.AmericanEnglish,
-				SearchLocation: am.Location{
+				SearchLocation: &am.Location{
 					Latitude:  34.985849,
 					Longitude: 135.7561864,
 				},
-				SearchRegion: am.Region{
+				SearchRegion: &am.Region{
 					NorthLatitude: 35.0219,
 					EastLongitude: 135.8426,
 					SouthLatitude: 34.8440,
 					WestLongitude: 135.6215,
 				},
-				UserLocation: am.Location{
+				UserLocation: &am.Location{
 					Latitude:  34.985849,
 					Longitude: 135.7561864,
 				},
@@ -420,19 +521,68 @@ func TestSearchAutoCompleteRequest_URLValues(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "address categories",
+			fields: fields{
+				Query:                    "Cupertino",
+				ResultTypeFilter:         []am.ResultType{am.ResultTypeAddress, am.ResultTypePhysicalFeature},
+				IncludeAddressCategories: []am.AddressCategory{am.AddressCategoryLocality, am.AddressCategoryPostalCode},
+				ExcludeAddressCategories: []am.AddressCategory{am.AddressCategoryCountry},
+			},
+			want: url.Values{
+				"q":                        []string{"Cupertino"},
+				"resultTypeFilter":         []string{"Address,PhysicalFeature"},
+				"includeAddressCategories": []string{"Locality,PostalCode"},
+				"excludeAddressCategories": []string{"Country"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown address category",
+			fields: fields{
+				Query:                    "Cupertino",
+				IncludeAddressCategories: []am.AddressCategory{"City"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "conflict address category",
+			fields: fields{
+				Query:                    "Cupertino",
+				IncludeAddressCategories: []am.AddressCategory{am.AddressCategoryLocality},
+				ExcludeAddressCategories: []am.AddressCategory{am.AddressCategoryLocality},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "query result type",
+			fields: fields{
+				Query:            "Cupertino",
+				ResultTypeFilter: []am.ResultType{am.ResultTypeQuery},
+			},
+			want: url.Values{
+				"q":                []string{"Cupertino"},
+				"resultTypeFilter": []string{"Query"},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			req := &am.SearchAutoCompleteRequest{
-				Query:                tt.fields.Query,
-				ExcludePoiCategories: tt.fields.ExcludePoiCategories,
-				IncludePoiCategories: tt.fields.IncludePoiCategories,
-				LimitToCountries:     tt.fields.LimitToCountries,
-				ResultTypeFilter:     tt.fields.ResultTypeFilter,
-				Lang:                 tt.fields.Lang,
-				SearchLocation:       tt.fields.SearchLocation,
-				SearchRegion:         tt.fields.SearchRegion,
-				UserLocation:         tt.fields.UserLocation,
+				Query:                    tt.fields.Query,
+				ExcludePoiCategories:     tt.fields.ExcludePoiCategories,
+				IncludePoiCategories:     tt.fields.IncludePoiCategories,
+				LimitToCountries:         tt.fields.LimitToCountries,
+				ResultTypeFilter:         tt.fields.ResultTypeFilter,
+				IncludeAddressCategories: tt.fields.IncludeAddressCategories,
+				ExcludeAddressCategories: tt.fields.ExcludeAddressCategories,
+				Lang:                     tt.fields.Lang,
+				SearchLocation:           tt.fields.SearchLocation,
+				SearchRegion:             tt.fields.SearchRegion,
+				UserLocation:             tt.fields.UserLocation,
 			}
 			got, err := req.URLValues()
 			if (err != nil) != tt.wantErr {
@@ -455,10 +605,10 @@ func TestDirectionsRequest_URLValues(t *testing.T) {
 		DepartureDate           time.Time
 		Lang                    language.Tag
 		RequestsAlternateRoutes bool
-		SearchLocation          am.Location
-		SearchRegion            am.Region
+		SearchLocation          *am.Location
+		SearchRegion            *am.Region
 		TransportType           am.TransportType
-		UserLocation            am.Location
+		UserLocation            *am.Location
 	}
 	tests := []struct {
 		name    string
@@ -482,18 +632,18 @@ func TestDirectionsRequest_URLValues(t *testing.T) {
 				Avoid:         []am.DirectionsAvoid{am.DirectionsAvoidTolls},
 				DepartureDate: time.Unix(1696484859, 0).Add(time.Hour * 2),
 				Lang:          language.AmericanEnglish,
-				SearchLocation: am.Location{
+				SearchLocation: &am.Location{
 					Latitude:  34.985849,
 					Longitude: 135.7561864,
 				},
-				SearchRegion: am.Region{
+				SearchRegion: &am.Region{
 					NorthLatitude: 35.0219,
 					EastLongitude: 135.8426,
 					SouthLatitude: 34.8440,
 					WestLongitude: 135.6215,
 				},
 				TransportType: am.TransportTypeAutomobile,
-				UserLocation: am.Location{
+				UserLocation: &am.Location{
 					Latitude:  34.985849,
 					Longitude: 135.7561864,
 				},
@@ -512,6 +662,40 @@ func TestDirectionsRequest_URLValues(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "transit",
+			fields: fields{
+				Origin:        am.OneOfLoc{Address: "Disneyland"},
+				Destination:   am.OneOfLoc{Address: "Universal Studios Hollywood"},
+				TransportType: am.TransportTypeTransit,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "cycling",
+			fields: fields{
+				Origin:        am.OneOfLoc{Address: "Disneyland"},
+				Destination:   am.OneOfLoc{Address: "Universal Studios Hollywood"},
+				TransportType: am.TransportTypeCycling,
+			},
+			want: url.Values{
+				"origin":        []string{"Disneyland"},
+				"destination":   []string{"Universal Studios Hollywood"},
+				"transportType": []string{"Cycling"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown transport type",
+			fields: fields{
+				Origin:        am.OneOfLoc{Address: "Disneyland"},
+				Destination:   am.OneOfLoc{Address: "Universal Studios Hollywood"},
+				TransportType: "automobile",
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -539,3 +723,104 @@ func TestDirectionsRequest_URLValues(t *testing.T) {
 		})
 	}
 }
+
+func TestPlacesRequest_URLValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     am.PlacesRequest
+		want    url.Values
+		wantErr bool
+	}{
+		{
+			name: "ids",
+			req: am.PlacesRequest{
+				IDs:  []string{"I63802885C8189B2B", "ICD5F9ABE8FB2E1B5"},
+				Lang: language.Japanese,
+			},
+			want: url.Values{
+				"ids":  []string{"I63802885C8189B2B,ICD5F9ABE8FB2E1B5"},
+				"lang": []string{"ja"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "no ids",
+			req:     am.PlacesRequest{},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty id",
+			req:     am.PlacesRequest{IDs: []string{"I63802885C8189B2B", ""}},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.req.URLValues()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PlacesRequest.URLValues() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlacesRequest.URLValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEtaRequest_URLValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     am.EtaRequest
+		want    url.Values
+		wantErr bool
+	}{
+		{
+			name: "transit",
+			req: am.EtaRequest{
+				Origin:        am.NewLocation(37.331423, -122.030503),
+				Destinations:  []am.Location{{Latitude: 37.32556561130194, Longitude: -121.94635203581443}},
+				TransportType: am.TransportTypeTransit,
+			},
+			want: url.Values{
+				"origin":        []string{"37.331423,-122.030503"},
+				"destinations":  []string{"37.32556561130194,-121.94635203581443"},
+				"transportType": []string{"Transit"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown transport type",
+			req: am.EtaRequest{
+				Origin:        am.NewLocation(37.331423, -122.030503),
+				Destinations:  []am.Location{{Latitude: 37.32556561130194, Longitude: -121.94635203581443}},
+				TransportType: "Teleport",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "too many destinations",
+			req: am.EtaRequest{
+				Origin:       am.NewLocation(37.331423, -122.030503),
+				Destinations: make([]am.Location, 11),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.req.URLValues()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EtaRequest.URLValues() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EtaRequest.URLValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
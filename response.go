@@ -40,8 +40,9 @@ func (e *ErrorFromAPI) Error() string {
 }
 
 type ErrorResponseError struct {
-	Details []string `json:"details"`
-	Message string   `json:"message"`
+	Details []string    `json:"details"`
+	Message string      `json:"message"`
+	Extra   ExtraFields `json:"-"`
 }
 
 // Original response from API.
@@ -49,32 +50,44 @@ type ErrorResponseError struct {
 // https://developer.apple.com/documentation/applemapsserverapi/errorresponse
 type ErrorResponse struct {
 	Error ErrorResponseError `json:"error"`
+	Extra ExtraFields        `json:"-"`
 }
 
 // https://developer.apple.com/documentation/applemapsserverapi/generate_a_maps_access_token
 type AccessTokenResponse struct {
-	AccessToken      string `json:"accessToken"`
-	ExpiresInSeconds int64  `json:"expiresInSeconds"`
+	AccessToken      string      `json:"accessToken"`
+	ExpiresInSeconds int64       `json:"expiresInSeconds"`
+	Extra            ExtraFields `json:"-"`
 }
 
 type MapRegion = Region
 
 // StructuredAddress is 'An object that describes the detailed address components of a place.'
 type StructuredAddress struct {
-	AdministrativeArea     string   `json:"administrativeArea"`     // The state or province of the place.
-	AdministrativeAreaCode string   `json:"administrativeAreaCode"` // The short code for the state or area.
-	AreasOfInterest        []string `json:"areasOfInterest"`        // Common names of the area in which the place resides.
-	DependentLocalities    []string `json:"dependentLocalities"`    // Common names for the local area or neighborhood of the place.
-	FullThoroughfare       string   `json:"fullThoroughfare"`       // A combination of thoroughfare and subthoroughfare.
-	Locality               string   `json:"locality"`               // The city of the place.
-	PostCode               string   `json:"postCode"`               // The postal code of the place.
-	SubLocality            string   `json:"subLocality"`            // The name of the area within the locality.
-	SubThoroughfare        string   `json:"subThoroughfare"`        // The number on the street at the place.
-	Thoroughfare           string   `json:"thoroughfare"`           // The street name at the place.
+	AdministrativeArea     string      `json:"administrativeArea"`     // The state or province of the place.
+	AdministrativeAreaCode string      `json:"administrativeAreaCode"` // The short code for the state or area.
+	AreasOfInterest        []string    `json:"areasOfInterest"`        // Common names of the area in which the place resides.
+	DependentLocalities    []string    `json:"dependentLocalities"`    // Common names for the local area or neighborhood of the place.
+	FullThoroughfare       string      `json:"fullThoroughfare"`       // A combination of thoroughfare and subthoroughfare.
+	Locality               string      `json:"locality"`               // The city of the place.
+	PostCode               string      `json:"postCode"`               // The postal code of the place.
+	SubAdministrativeArea  string      `json:"subAdministrativeArea"`  // The county or similar area of the place.
+	SubLocality            string      `json:"subLocality"`            // The name of the area within the locality.
+	SubThoroughfare        string      `json:"subThoroughfare"`        // The number on the street at the place.
+	Thoroughfare           string      `json:"thoroughfare"`           // The street name at the place.
+	Extra                  ExtraFields `json:"-"`
 }
 
 // https://developer.apple.com/documentation/applemapsserverapi/place
 type Place struct {
+	// An opaque string that identifies the place, stable enough to be stored
+	// and looked up later via Client.Place.
+	ID string `json:"id,omitempty"`
+
+	// Other identifiers the same place is known by, for example the ID it had
+	// before Apple merged two places.
+	AlternateIDs []string `json:"alternateIds,omitempty"`
+
 	// The country or region of the place.
 	Country string `json:"country"`
 
@@ -100,15 +113,63 @@ type Place struct {
 
 	// A StructuredAddress object that describes details of the place’s address.
 	StructuredAddress StructuredAddress `json:"structuredAddress"`
+
+	// The point of interest category of the place, empty for addresses.
+	PoiCategory PoiCategory `json:"poiCategory,omitempty"`
+
+	// The phone number of the place, in E.164 format. For example, +14153552838.
+	Telephone string `json:"telephone,omitempty"`
+
+	// Websites of the place.
+	URLs []string `json:"urls,omitempty"`
+
+	// The center of the place, may differ from Coordinate for large places
+	// where Coordinate is an entrance or a routable point.
+	Center *Location `json:"center,omitempty"`
+
+	Extra ExtraFields `json:"-"`
 }
 
 type PlaceResults struct {
-	Results []Place `json:"results"`
+	Results []Place     `json:"results"`
+	Extra   ExtraFields `json:"-"`
+}
+
+// https://developer.apple.com/documentation/applemapsserverapi/paginationinfo
+type PaginationInfo struct {
+	// The token to fetch the next page, empty on the last page.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+
+	// The token to fetch the previous page, empty on the first page.
+	PrevPageToken string `json:"prevPageToken,omitempty"`
+
+	// The total number of pages.
+	TotalPageCount int64 `json:"totalPageCount,omitempty"`
+
+	// The total number of results across all pages.
+	TotalResults int64 `json:"totalResults,omitempty"`
+
+	Extra ExtraFields `json:"-"`
 }
 
+// https://developer.apple.com/documentation/applemapsserverapi/searchresponse
 type SearchResponse struct {
 	DisplayMapRegion MapRegion `json:"displayMapRegion"`
 	Results          []Place   `json:"results"`
+
+	// Only present when SearchRequest.EnablePagination is true.
+	PaginationInfo *PaginationInfo `json:"paginationInfo,omitempty"`
+
+	Extra ExtraFields `json:"-"`
+}
+
+// NextPageToken returns the token of the next page, or empty string if there
+// is no more page.
+func (resp *SearchResponse) NextPageToken() string {
+	if resp.PaginationInfo == nil {
+		return ""
+	}
+	return resp.PaginationInfo.NextPageToken
 }
 
 // https://developer.apple.com/documentation/applemapsserverapi/autocompleteresult
@@ -131,11 +192,14 @@ type AutocompleteResult struct {
 	// A StructuredAddress object that describes the detailed address components
 	// of a place.
 	StructuredAddress StructuredAddress `json:"structuredAddress"`
+
+	Extra ExtraFields `json:"-"`
 }
 
 // https://developer.apple.com/documentation/applemapsserverapi/searchautocompleteresponse
 type SearchAutocompleteResponse struct {
 	Results []AutocompleteResult `json:"results"`
+	Extra   ExtraFields          `json:"-"`
 }
 
 type DirectionsResponseRoute struct {
@@ -165,8 +229,10 @@ type DirectionsResponseRoute struct {
 	// A string that represents the mode of transportation the service used to
 	// estimate the arrival time. Same as the input query param transportType or
 	// Automobile if the input query didn’t specify a transportation type.
-	// Possible Values: Automobile, Walking
+	// Possible Values: Automobile, Walking, Cycling
 	TransportType TransportType `json:"transportType"`
+
+	Extra ExtraFields `json:"-"`
 }
 
 type DirectionsResponseStep struct {
@@ -190,8 +256,10 @@ type DirectionsResponseStep struct {
 
 	// A string indicating the transport type for this step if it’s different
 	// from the transportType in the route.
-	// Possible Values: Automobile, Walking
+	// Possible Values: Automobile, Walking, Cycling
 	TransportType TransportType `json:"transportType"`
+
+	Extra ExtraFields `json:"-"`
 }
 
 // https://developer.apple.com/documentation/applemapsserverapi/directionsresponse
@@ -214,6 +282,8 @@ type DirectionsResponse struct {
 	// index into this array. Each step in turn references its path based on
 	// indexes into the stepPaths array.
 	Steps []DirectionsResponseStep `json:"steps"`
+
+	Extra ExtraFields `json:"-"`
 }
 
 // https://developer.apple.com/documentation/applemapsserverapi/etaresponse/eta
@@ -231,14 +301,60 @@ type EtaResponseEta struct {
 	StaticTravelTimeSeconds int64 `json:"staticTravelTimeSeconds"`
 
 	// A string that represents the mode of transportation for this ETA, which is one of:
-	// Automobile, Walking, Transit
+	// Automobile, Walking, Transit, Cycling
 	//
 	// NOTE(ringsaturn): Apple's example use `AUTOMOBILE`, who knows why.
+	// It's normalized to TransportTypeAutomobile on unmarshal.
 	TransportType TransportType `json:"transportType"`
+
+	Extra ExtraFields `json:"-"`
 }
 
 // https://developer.apple.com/documentation/applemapsserverapi/etaresponse
 type EtaResponse struct {
 	// An array of one or more EtaResponse.Eta objects.
 	Etas []EtaResponseEta `json:"etas"`
+
+	Extra ExtraFields `json:"-"`
+}
+
+// https://developer.apple.com/documentation/applemapsserverapi/placelookuperror
+type PlaceLookupError struct {
+	// The reason the lookup failed, for example FAILED_INVALID_ID or
+	// FAILED_NOT_FOUND.
+	ErrorCode string `json:"errorCode"`
+
+	// The identifier the error is for.
+	ID string `json:"id"`
+
+	Extra ExtraFields `json:"-"`
+}
+
+// https://developer.apple.com/documentation/applemapsserverapi/placesresponse
+type PlacesResponse struct {
+	// The places found, in no particular order.
+	Results []Place `json:"results"`
+
+	// The identifiers that failed to resolve.
+	Errors []PlaceLookupError `json:"errors"`
+
+	Extra ExtraFields `json:"-"`
+}
+
+// https://developer.apple.com/documentation/applemapsserverapi/alternateidsentry
+type AlternateIDsEntry struct {
+	// The identifier from the request.
+	ID string `json:"id"`
+
+	// The alternate identifiers of the place.
+	AlternateIDs []string `json:"alternateIds"`
+
+	Extra ExtraFields `json:"-"`
+}
+
+// https://developer.apple.com/documentation/applemapsserverapi/alternateidsresponse
+type AlternateIDsResponse struct {
+	Results []AlternateIDsEntry `json:"results"`
+	Errors  []PlaceLookupError  `json:"errors"`
+	Extra   ExtraFields         `json:"-"`
 }
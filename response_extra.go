@@ -0,0 +1,307 @@
+package am
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ExtraFields holds the JSON fields of a response object that the model has
+// no field for, usually because Apple added them after this SDK was released.
+//
+// They are kept as-is on unmarshal and written back on marshal, so a response
+// can be passed through losslessly.
+//
+// Location and Region, and so MapRegion, have no Extra: they are also request
+// types and are compared with ==, which a map field would prevent. Unknown
+// fields of coordinates, display map regions, step path points and ETA
+// destinations are dropped.
+type ExtraFields map[string]json.RawMessage
+
+var knownJSONFieldsCache sync.Map // reflect.Type -> map[string]bool
+
+// knownJSONFields returns the lower-cased JSON names of t's fields, lower-cased
+// because encoding/json matches keys case-insensitively.
+func knownJSONFields(t reflect.Type) map[string]bool {
+	if known, ok := knownJSONFieldsCache.Load(t); ok {
+		return known.(map[string]bool)
+	}
+	known := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[strings.ToLower(name)] = true
+	}
+	knownJSONFieldsCache.Store(t, known)
+	return known
+}
+
+// unmarshalWithExtra decodes data into v, which must be a pointer to a struct
+// without UnmarshalJSON, and stores the unknown fields to extra.
+func unmarshalWithExtra(data []byte, v any, extra *ExtraFields) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	*extra = nil
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	all := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	known := knownJSONFields(reflect.TypeOf(v).Elem())
+	for key, value := range all {
+		if known[strings.ToLower(key)] {
+			continue
+		}
+		if *extra == nil {
+			*extra = ExtraFields{}
+		}
+		(*extra)[key] = value
+	}
+	return nil
+}
+
+// marshalWithExtra encodes v, which must be a struct without MarshalJSON, and
+// appends extra to the object. Keys of extra that collide with a field of v
+// are ignored.
+func marshalWithExtra(v any, extra ExtraFields) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	known := knownJSONFields(reflect.TypeOf(v))
+	keys := make([]string, 0, len(extra))
+	for key := range extra {
+		if !known[strings.ToLower(key)] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	buf := bytes.NewBuffer(data[:len(data)-1])
+	empty := len(bytes.TrimSpace(data)) == 2 // {}
+	for _, key := range keys {
+		value := extra[key]
+		if !json.Valid(value) {
+			return nil, fmt.Errorf("am: invalid JSON in extra field %q", key)
+		}
+		if !empty {
+			buf.WriteByte(',')
+		}
+		empty = false
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (v *ErrorResponseError) UnmarshalJSON(data []byte) error {
+	type plain ErrorResponseError
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v ErrorResponseError) MarshalJSON() ([]byte, error) {
+	type plain ErrorResponseError
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *ErrorResponse) UnmarshalJSON(data []byte) error {
+	type plain ErrorResponse
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v ErrorResponse) MarshalJSON() ([]byte, error) {
+	type plain ErrorResponse
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *AccessTokenResponse) UnmarshalJSON(data []byte) error {
+	type plain AccessTokenResponse
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v AccessTokenResponse) MarshalJSON() ([]byte, error) {
+	type plain AccessTokenResponse
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *StructuredAddress) UnmarshalJSON(data []byte) error {
+	type plain StructuredAddress
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v StructuredAddress) MarshalJSON() ([]byte, error) {
+	type plain StructuredAddress
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *Place) UnmarshalJSON(data []byte) error {
+	type plain Place
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v Place) MarshalJSON() ([]byte, error) {
+	type plain Place
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *PlaceResults) UnmarshalJSON(data []byte) error {
+	type plain PlaceResults
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v PlaceResults) MarshalJSON() ([]byte, error) {
+	type plain PlaceResults
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *PaginationInfo) UnmarshalJSON(data []byte) error {
+	type plain PaginationInfo
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v PaginationInfo) MarshalJSON() ([]byte, error) {
+	type plain PaginationInfo
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *SearchResponse) UnmarshalJSON(data []byte) error {
+	type plain SearchResponse
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v SearchResponse) MarshalJSON() ([]byte, error) {
+	type plain SearchResponse
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *AutocompleteResult) UnmarshalJSON(data []byte) error {
+	type plain AutocompleteResult
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v AutocompleteResult) MarshalJSON() ([]byte, error) {
+	type plain AutocompleteResult
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *SearchAutocompleteResponse) UnmarshalJSON(data []byte) error {
+	type plain SearchAutocompleteResponse
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v SearchAutocompleteResponse) MarshalJSON() ([]byte, error) {
+	type plain SearchAutocompleteResponse
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *DirectionsResponseRoute) UnmarshalJSON(data []byte) error {
+	type plain DirectionsResponseRoute
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v DirectionsResponseRoute) MarshalJSON() ([]byte, error) {
+	type plain DirectionsResponseRoute
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *DirectionsResponseStep) UnmarshalJSON(data []byte) error {
+	type plain DirectionsResponseStep
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v DirectionsResponseStep) MarshalJSON() ([]byte, error) {
+	type plain DirectionsResponseStep
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *DirectionsResponse) UnmarshalJSON(data []byte) error {
+	type plain DirectionsResponse
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v DirectionsResponse) MarshalJSON() ([]byte, error) {
+	type plain DirectionsResponse
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *EtaResponseEta) UnmarshalJSON(data []byte) error {
+	type plain EtaResponseEta
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v EtaResponseEta) MarshalJSON() ([]byte, error) {
+	type plain EtaResponseEta
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *EtaResponse) UnmarshalJSON(data []byte) error {
+	type plain EtaResponse
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v EtaResponse) MarshalJSON() ([]byte, error) {
+	type plain EtaResponse
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *PlaceLookupError) UnmarshalJSON(data []byte) error {
+	type plain PlaceLookupError
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v PlaceLookupError) MarshalJSON() ([]byte, error) {
+	type plain PlaceLookupError
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *PlacesResponse) UnmarshalJSON(data []byte) error {
+	type plain PlacesResponse
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v PlacesResponse) MarshalJSON() ([]byte, error) {
+	type plain PlacesResponse
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *AlternateIDsEntry) UnmarshalJSON(data []byte) error {
+	type plain AlternateIDsEntry
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v AlternateIDsEntry) MarshalJSON() ([]byte, error) {
+	type plain AlternateIDsEntry
+	return marshalWithExtra(plain(v), v.Extra)
+}
+
+func (v *AlternateIDsResponse) UnmarshalJSON(data []byte) error {
+	type plain AlternateIDsResponse
+	return unmarshalWithExtra(data, (*plain)(v), &v.Extra)
+}
+
+func (v AlternateIDsResponse) MarshalJSON() ([]byte, error) {
+	type plain AlternateIDsResponse
+	return marshalWithExtra(plain(v), v.Extra)
+}
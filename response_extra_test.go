@@ -0,0 +1,108 @@
+package am_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtraFieldsRoundTrip(t *testing.T) {
+	data := `{
+		"displayMapRegion": {"eastLongitude": 2.3, "northLatitude": 48.9, "southLatitude": 48.8, "westLongitude": 2.2},
+		"results": [
+			{
+				"name": "Eiffel Tower",
+				"country": "France",
+				"countryCode": "FR",
+				"coordinate": {"latitude": 48.858, "longitude": 2.294},
+				"displayMapRegion": {"eastLongitude": 0, "northLatitude": 0, "southLatitude": 0, "westLongitude": 0},
+				"formattedAddressLines": ["5 Avenue Anatole France"],
+				"structuredAddress": {
+					"administrativeArea": "Île-de-France",
+					"administrativeAreaCode": "",
+					"areasOfInterest": null,
+					"dependentLocalities": null,
+					"fullThoroughfare": "",
+					"locality": "Paris",
+					"postCode": "",
+					"subAdministrativeArea": "",
+					"subLocality": "",
+					"subThoroughfare": "",
+					"thoroughfare": "",
+					"neighborhood": "Gros-Caillou"
+				},
+				"rating": {"score": 4.7, "count": 1024}
+			}
+		],
+		"newTopLevelField": [1, 2, 3]
+	}`
+	resp := &am.SearchResponse{}
+	if err := json.Unmarshal([]byte(data), resp); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Eiffel Tower", resp.Results[0].Name)
+	assert.JSONEq(t, `[1, 2, 3]`, string(resp.Extra["newTopLevelField"]))
+	assert.JSONEq(t, `{"score": 4.7, "count": 1024}`, string(resp.Results[0].Extra["rating"]))
+	assert.JSONEq(t, `"Gros-Caillou"`, string(resp.Results[0].StructuredAddress.Extra["neighborhood"]))
+
+	got, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.JSONEq(t, data, string(got))
+}
+
+func TestExtraFieldsNestedLocationsDropped(t *testing.T) {
+	var place am.Place
+	err := json.Unmarshal([]byte(`{
+		"name": "a",
+		"coordinate": {"latitude": 1, "longitude": 2, "altitude": 3},
+		"displayMapRegion": {"eastLongitude": 1, "northLatitude": 1, "southLatitude": 0, "westLongitude": 0, "rotation": 4},
+		"other": 5
+	}`), &place)
+	assert.NoError(t, err)
+	assert.Equal(t, am.ExtraFields{"other": json.RawMessage("5")}, place.Extra)
+	b, err := json.Marshal(place)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "altitude")
+	assert.NotContains(t, string(b), "rotation")
+}
+
+func TestExtraFieldsKnownKeyIgnored(t *testing.T) {
+	resp := am.EtaResponse{
+		Extra: am.ExtraFields{
+			"etas":  json.RawMessage(`"shadowed"`),
+			"debug": json.RawMessage(`true`),
+		},
+	}
+	got, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.JSONEq(t, `{"etas": null, "debug": true}`, string(got))
+}
+
+func TestExtraFieldsInvalidJSON(t *testing.T) {
+	resp := am.EtaResponse{
+		Extra: am.ExtraFields{"debug": json.RawMessage(`{`)},
+	}
+	_, err := json.Marshal(resp)
+	assert.Error(t, err)
+}
+
+func TestExtraFieldsEmptyObject(t *testing.T) {
+	entry := &am.PlaceLookupError{}
+	if err := json.Unmarshal([]byte(`{"errorCode":"FAILED_NOT_FOUND","id":"I1","retryable":false}`), entry); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "FAILED_NOT_FOUND", entry.ErrorCode)
+	assert.JSONEq(t, `false`, string(entry.Extra["retryable"]))
+
+	// Reused value must not keep the stale extra fields.
+	if err := json.Unmarshal([]byte(`{"errorCode":"FAILED_INVALID_ID","id":"I2"}`), entry); err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, entry.Extra)
+}
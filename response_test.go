@@ -3,6 +3,9 @@ package am_test
 import (
 	_ "embed"
 	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	am "github.com/ringsaturn/am"
@@ -21,11 +24,111 @@ var (
 
 	//go:embed testdata/error_response_1.json
 	expectErrorResponse1 []byte
+
+	//go:embed testdata/place_response_1.json
+	expectPlaceResponse1 []byte
+
+	//go:embed testdata/places_response_1.json
+	expectPlacesResponse1 []byte
+
+	//go:embed testdata/alternate_ids_response_1.json
+	expectAlternateIDsResponse1 []byte
+
+	//go:embed testdata/search_autocomplete_response_1.json
+	expectSearchAutocompleteResponse1 []byte
+
+	//go:embed testdata/eta_response_1.json
+	expectEtaResponse1 []byte
 )
 
+// strictUnmarshal fails on fields the model doesn't have, so fixtures with
+// fields Apple added catch the drift.
+//
+// Response types keep unknown fields in Extra rather than failing, so check
+// every Extra is empty after decoding.
+func strictUnmarshal(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	if paths := unknownFields(reflect.ValueOf(v), ""); len(paths) > 0 {
+		return fmt.Errorf("unknown fields: %s", strings.Join(paths, ", "))
+	}
+	return nil
+}
+
+func unknownFields(v reflect.Value, path string) []string {
+	paths := []string{}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			paths = append(paths, unknownFields(v.Elem(), path)...)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			paths = append(paths, unknownFields(v.Index(i), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if extra, ok := v.Field(i).Interface().(am.ExtraFields); ok {
+				for key := range extra {
+					paths = append(paths, path+"."+key)
+				}
+				continue
+			}
+			paths = append(paths, unknownFields(v.Field(i), path+"."+field.Name)...)
+		}
+	}
+	return paths
+}
+
+func TestUnknownFields_Unexported(t *testing.T) {
+	// DirectionsRoute has an unexported pointer back to the response.
+	route := loadDirectionsResponse(t).Route(0)
+	route.Extra = am.ExtraFields{"new": json.RawMessage("1")}
+	assert.Equal(t, []string{".DirectionsResponseRoute.new"}, unknownFields(reflect.ValueOf(route), ""))
+}
+
+func TestFixturesStrictDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		v    any
+	}{
+		{name: "error_response_1", data: expectErrorResponse1, v: &am.ErrorResponse{}},
+		{name: "place_results_response_1", data: expectPlaceResultsResponse1, v: &am.PlaceResults{}},
+		{name: "search_response_1", data: expectSearchResponse1, v: &am.SearchResponse{}},
+		{name: "direction_response_1", data: expectDirectionResponse1, v: &am.DirectionsResponse{}},
+		{name: "place_response_1", data: expectPlaceResponse1, v: &am.Place{}},
+		{name: "places_response_1", data: expectPlacesResponse1, v: &am.PlacesResponse{}},
+		{name: "alternate_ids_response_1", data: expectAlternateIDsResponse1, v: &am.AlternateIDsResponse{}},
+		{name: "search_autocomplete_response_1", data: expectSearchAutocompleteResponse1, v: &am.SearchAutocompleteResponse{}},
+		{name: "eta_response_1", data: expectEtaResponse1, v: &am.EtaResponse{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := strictUnmarshal(tt.data, tt.v); err != nil {
+				t.Errorf("fixture has fields the model doesn't have: %v", err)
+			}
+		})
+	}
+}
+
+func TestStrictUnmarshalUnknownField(t *testing.T) {
+	data := []byte(`{"results":[{"name":"Apple Park","structuredAddress":{"locality":"Cupertino","neighborhood":"Pruneridge"}}]}`)
+	err := strictUnmarshal(data, &am.PlaceResults{})
+	if err == nil {
+		t.Fatal("expect error for unknown field")
+	}
+	assert.Contains(t, err.Error(), ".Results[0].StructuredAddress.neighborhood")
+}
+
 func TestErrorResponseUnmarshal(t *testing.T) {
 	expect := &am.ErrorResponse{}
-	err := json.Unmarshal(expectErrorResponse1, expect)
+	err := strictUnmarshal(expectErrorResponse1, expect)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -34,7 +137,7 @@ func TestErrorResponseUnmarshal(t *testing.T) {
 
 func TestPlaceResultsResponseUnmarshal(t *testing.T) {
 	expect := &am.PlaceResults{}
-	err := json.Unmarshal(expectPlaceResultsResponse1, expect)
+	err := strictUnmarshal(expectPlaceResultsResponse1, expect)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -44,7 +147,7 @@ func TestPlaceResultsResponseUnmarshal(t *testing.T) {
 
 func TestSearchResponseUnmarshal(t *testing.T) {
 	expect := &am.SearchResponse{}
-	err := json.Unmarshal(expectSearchResponse1, expect)
+	err := strictUnmarshal(expectSearchResponse1, expect)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -54,11 +157,72 @@ func TestSearchResponseUnmarshal(t *testing.T) {
 
 func TestDirectionResponseUnmarshal(t *testing.T) {
 	expect := &am.DirectionsResponse{}
-	err := json.Unmarshal(expectDirectionResponse1, expect)
+	err := strictUnmarshal(expectDirectionResponse1, expect)
 	if err != nil {
 		t.Fatal(err)
 	}
 	assert.Equal(t, 3, len(expect.Routes))
 	assert.Equal(t, 3, len(expect.StepPaths[1]))
+	// The fixture has "AUTOMOBILE".
+	assert.Equal(t, am.TransportTypeAutomobile, expect.Routes[0].TransportType)
+
+}
 
+func TestPlaceResponseUnmarshal(t *testing.T) {
+	expect := &am.Place{}
+	err := strictUnmarshal(expectPlaceResponse1, expect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "I63802885C8189B2B", expect.ID)
+	assert.Equal(t, []string{"I7F6A2D4E91C0B3A8"}, expect.AlternateIDs)
+	assert.Equal(t, "Apple Park", expect.Name)
+	assert.Equal(t, am.Landmark, expect.PoiCategory)
+}
+
+func TestPlacesResponseUnmarshal(t *testing.T) {
+	expect := &am.PlacesResponse{}
+	err := strictUnmarshal(expectPlacesResponse1, expect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(expect.Results))
+	assert.Equal(t, "I63802885C8189B2B", expect.Results[0].ID)
+	assert.Equal(t, 1, len(expect.Errors))
+	assert.Equal(t, "FAILED_NOT_FOUND", expect.Errors[0].ErrorCode)
+}
+
+func TestAlternateIDsResponseUnmarshal(t *testing.T) {
+	expect := &am.AlternateIDsResponse{}
+	err := strictUnmarshal(expectAlternateIDsResponse1, expect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(expect.Results))
+	assert.Equal(t, []string{"I63802885C8189B2B"}, expect.Results[0].AlternateIDs)
+	assert.Equal(t, "invalid", expect.Errors[0].ID)
+}
+
+func TestDirectionResponseDestinationDetails(t *testing.T) {
+	expect := &am.DirectionsResponse{}
+	err := strictUnmarshal(expectDirectionResponse1, expect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	destination := expect.Destination
+	assert.Equal(t, "+14153552838", destination.Telephone)
+	assert.Equal(t, []string{"https://sfpl.org/locations/mission-bay"}, destination.URLs)
+	assert.Equal(t, &am.Location{Latitude: 37.7753881, Longitude: -122.3931773}, destination.Center)
+	assert.Equal(t, "San Francisco County", destination.StructuredAddress.SubAdministrativeArea)
+}
+
+func TestEtaResponseUnmarshal(t *testing.T) {
+	expect := &am.EtaResponse{}
+	err := strictUnmarshal(expectEtaResponse1, expect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(expect.Etas))
+	assert.Equal(t, am.TransportTypeAutomobile, expect.Etas[0].TransportType)
+	assert.Equal(t, int64(935), expect.Etas[0].ExpectedTravelTimeSeconds)
 }
@@ -0,0 +1,106 @@
+package am
+
+import "context"
+
+// SearchIterator walks all pages of a search lazily, a page is only fetched
+// when the results of the previous one are consumed.
+//
+//	it := am.SearchAll(ctx, client, req, 100)
+//	for it.Next() {
+//		place := it.Place()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type SearchIterator struct {
+	ctx        context.Context
+	search     func(context.Context, *SearchRequest) (*SearchResponse, error)
+	req        SearchRequest
+	maxResults int
+
+	fetched bool
+	page    []Place
+	place   Place
+	count   int
+	done    bool
+	err     error
+}
+
+// SearchAll iterates the results of all pages of client.Search, stops after
+// maxResults places if maxResults > 0.
+func SearchAll(ctx context.Context, client Client, req *SearchRequest, maxResults int) *SearchIterator {
+	return newSearchIterator(ctx, client.Search, req, maxResults)
+}
+
+func newSearchIterator(
+	ctx context.Context,
+	search func(context.Context, *SearchRequest) (*SearchResponse, error),
+	req *SearchRequest,
+	maxResults int,
+) *SearchIterator {
+	it := &SearchIterator{
+		ctx:        ctx,
+		search:     search,
+		maxResults: maxResults,
+	}
+	if req != nil {
+		it.req = *req
+	}
+	it.req.EnablePagination = true
+	return it
+}
+
+// Next advances to the next place. It returns false when all pages are
+// consumed, maxResults is reached, the context is done or a request failed.
+// Check Err after Next returns false.
+//
+// A done context stops the iteration right away, even if places of the
+// current page are left.
+func (it *SearchIterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+	if it.maxResults > 0 && it.count >= it.maxResults {
+		it.done = true
+		return false
+	}
+	for len(it.page) == 0 {
+		if it.fetched && it.req.PageToken == "" {
+			it.done = true
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		resp, err := it.search(it.ctx, &it.req)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.fetched = true
+		next := resp.NextPageToken()
+		if next == it.req.PageToken {
+			// Same token again, stop here rather than looping forever.
+			next = ""
+		}
+		it.req.PageToken = next
+		it.page = resp.Results
+	}
+	it.place = it.page[0]
+	it.page = it.page[1:]
+	it.count++
+	return true
+}
+
+// Place returns the current place, only valid after Next returned true.
+func (it *SearchIterator) Place() Place { return it.place }
+
+// Err returns the error stopped the iteration, nil if all pages are consumed
+// or maxResults is reached.
+func (it *SearchIterator) Err() error { return it.err }
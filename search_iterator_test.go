@@ -0,0 +1,102 @@
+package am_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPagedSearchClient(t *testing.T, calls *int32) am.Client {
+	pages := map[string]am.SearchResponse{
+		"": {
+			Results:        []am.Place{{Name: "1"}, {Name: "2"}},
+			PaginationInfo: &am.PaginationInfo{NextPageToken: "p2"},
+		},
+		"p2": {
+			Results:        []am.Place{{Name: "3"}},
+			PaginationInfo: &am.PaginationInfo{NextPageToken: "p3"},
+		},
+		"p3": {
+			Results: []am.Place{{Name: "4"}, {Name: "5"}},
+		},
+	}
+	return newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		assert.Equal(t, "true", r.URL.Query().Get("enablePagination"))
+		page, ok := pages[r.URL.Query().Get("pageToken")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write(expectErrorResponse1)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	})
+}
+
+func collectPlaceNames(it *am.SearchIterator) []string {
+	names := []string{}
+	for it.Next() {
+		names = append(names, it.Place().Name)
+	}
+	return names
+}
+
+func TestSearchAll(t *testing.T) {
+	var calls int32
+	client := newPagedSearchClient(t, &calls)
+	it := am.SearchAll(context.Background(), client, &am.SearchRequest{Query: "coffee"}, 0)
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, collectPlaceNames(it))
+	assert.NoError(t, it.Err())
+	assert.Equal(t, int32(3), calls)
+	assert.False(t, it.Next())
+}
+
+func TestSearchAll_MaxResults(t *testing.T) {
+	var calls int32
+	client := newPagedSearchClient(t, &calls)
+	it := am.SearchAll(context.Background(), client, &am.SearchRequest{Query: "coffee"}, 3)
+	assert.Equal(t, []string{"1", "2", "3"}, collectPlaceNames(it))
+	assert.NoError(t, it.Err())
+	// The third page is never fetched.
+	assert.Equal(t, int32(2), calls)
+}
+
+func TestSearchAll_ContextCanceled(t *testing.T) {
+	var calls int32
+	client := newPagedSearchClient(t, &calls)
+	ctx, cancel := context.WithCancel(context.Background())
+	it := am.SearchAll(ctx, client, &am.SearchRequest{Query: "coffee"}, 0)
+	assert.True(t, it.Next())
+	assert.True(t, it.Next())
+	cancel()
+	assert.False(t, it.Next())
+	assert.True(t, errors.Is(it.Err(), context.Canceled))
+	assert.Equal(t, int32(1), calls)
+
+	// Places left in the page aren't returned either.
+	ctx, cancel = context.WithCancel(context.Background())
+	it = am.SearchAll(ctx, client, &am.SearchRequest{Query: "coffee"}, 0)
+	assert.True(t, it.Next())
+	assert.Equal(t, "1", it.Place().Name)
+	cancel()
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+	assert.False(t, it.Next())
+	assert.Equal(t, int32(2), calls)
+}
+
+func TestSearchAll_Error(t *testing.T) {
+	var calls int32
+	client := newPagedSearchClient(t, &calls)
+	it := am.SearchAll(context.Background(), client, &am.SearchRequest{Query: "coffee", PageToken: "unknown"}, 0)
+	assert.Empty(t, collectPlaceNames(it))
+	var apiErr *am.ErrorFromAPI
+	assert.True(t, errors.As(it.Err(), &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
@@ -0,0 +1,211 @@
+package am
+
+import (
+	"container/heap"
+	"math"
+)
+
+// The mean radius of the Earth in metres.
+const earthRadiusMeters = 6371008.8
+
+// SimplifyFunc simplifies a polyline, dropping points until the result
+// deviates from the original by about tolerance metres. The first and the
+// last points are always kept.
+type SimplifyFunc func(locs []Location, tolerance float64) []Location
+
+var (
+	_ SimplifyFunc = SimplifyDouglasPeucker
+	_ SimplifyFunc = SimplifyVisvalingam
+)
+
+type planePoint struct {
+	x, y float64
+}
+
+// projectLocal projects the points on a plane in metres, with an
+// equirectangular projection centered on their mean latitude. It's accurate
+// enough for the few kilometres between the points of a route.
+//
+// Longitudes are unwrapped from the previous point, so a polyline crossing
+// the antimeridian stays continuous.
+func projectLocal(locs []Location) []planePoint {
+	if len(locs) == 0 {
+		return nil
+	}
+	var meanLat float64
+	for _, loc := range locs {
+		meanLat += loc.Latitude
+	}
+	meanLat /= float64(len(locs))
+	scale := math.Pi / 180 * earthRadiusMeters
+	cos := math.Cos(meanLat * math.Pi / 180)
+
+	points := make([]planePoint, len(locs))
+	lon := locs[0].Longitude
+	for i, loc := range locs {
+		if i > 0 {
+			lon += math.Remainder(loc.Longitude-locs[i-1].Longitude, 360)
+		}
+		points[i] = planePoint{x: lon * scale * cos, y: loc.Latitude * scale}
+	}
+	return points
+}
+
+// segmentDistance returns the distance from p to the segment [a, b].
+func segmentDistance(p, a, b planePoint) float64 {
+	dx, dy := b.x-a.x, b.y-a.y
+	if dx == 0 && dy == 0 {
+		return math.Hypot(p.x-a.x, p.y-a.y)
+	}
+	t := ((p.x-a.x)*dx + (p.y-a.y)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(p.x-(a.x+t*dx), p.y-(a.y+t*dy))
+}
+
+func triangleArea(a, b, c planePoint) float64 {
+	return math.Abs((b.x-a.x)*(c.y-a.y)-(c.x-a.x)*(b.y-a.y)) / 2
+}
+
+func keptLocations(locs []Location, keep []bool) []Location {
+	simplified := make([]Location, 0, len(locs))
+	for i, loc := range locs {
+		if keep[i] {
+			simplified = append(simplified, loc)
+		}
+	}
+	return simplified
+}
+
+// SimplifyDouglasPeucker simplifies the polyline with the Ramer–Douglas–
+// Peucker algorithm: every dropped point is within tolerance metres of the
+// simplified polyline.
+func SimplifyDouglasPeucker(locs []Location, tolerance float64) []Location {
+	if len(locs) < 3 || tolerance <= 0 {
+		return append([]Location(nil), locs...)
+	}
+	points := projectLocal(locs)
+	keep := make([]bool, len(locs))
+	keep[0], keep[len(locs)-1] = true, true
+
+	stack := [][2]int{{0, len(locs) - 1}}
+	for len(stack) > 0 {
+		first, last := stack[len(stack)-1][0], stack[len(stack)-1][1]
+		stack = stack[:len(stack)-1]
+
+		farthest, maxDistance := -1, tolerance
+		for i := first + 1; i < last; i++ {
+			if d := segmentDistance(points[i], points[first], points[last]); d > maxDistance {
+				farthest, maxDistance = i, d
+			}
+		}
+		if farthest < 0 {
+			continue
+		}
+		keep[farthest] = true
+		stack = append(stack, [2]int{first, farthest}, [2]int{farthest, last})
+	}
+	return keptLocations(locs, keep)
+}
+
+type visvalingamPoint struct {
+	index      int
+	area       float64
+	prev, next int
+	heapIndex  int
+}
+
+type visvalingamHeap []*visvalingamPoint
+
+func (h visvalingamHeap) Len() int           { return len(h) }
+func (h visvalingamHeap) Less(i, j int) bool { return h[i].area < h[j].area }
+
+func (h visvalingamHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].heapIndex, h[j].heapIndex = i, j
+}
+
+func (h *visvalingamHeap) Push(x any) {
+	p := x.(*visvalingamPoint)
+	p.heapIndex = len(*h)
+	*h = append(*h, p)
+}
+
+func (h *visvalingamHeap) Pop() any {
+	old := *h
+	p := old[len(old)-1]
+	p.heapIndex = -1
+	*h = old[:len(old)-1]
+	return p
+}
+
+// SimplifyVisvalingam simplifies the polyline with the Visvalingam–Whyatt
+// algorithm: points are dropped while the triangle they form with their
+// neighbours is smaller than tolerance² square metres.
+//
+// It tends to keep the overall shape better than SimplifyDouglasPeucker, but
+// gives no bound on the distance of a dropped point.
+func SimplifyVisvalingam(locs []Location, tolerance float64) []Location {
+	if len(locs) < 3 || tolerance <= 0 {
+		return append([]Location(nil), locs...)
+	}
+	points := projectLocal(locs)
+	threshold := tolerance * tolerance
+
+	nodes := make([]visvalingamPoint, len(locs))
+	h := make(visvalingamHeap, 0, len(locs)-2)
+	for i := range nodes {
+		nodes[i] = visvalingamPoint{index: i, prev: i - 1, next: i + 1, heapIndex: -1}
+		if i > 0 && i < len(locs)-1 {
+			nodes[i].area = triangleArea(points[i-1], points[i], points[i+1])
+			heap.Push(&h, &nodes[i])
+		}
+	}
+	area := func(p *visvalingamPoint) float64 {
+		return triangleArea(points[p.prev], points[p.index], points[p.next])
+	}
+
+	keep := make([]bool, len(locs))
+	for i := range keep {
+		keep[i] = true
+	}
+	for h.Len() > 0 {
+		p := heap.Pop(&h).(*visvalingamPoint)
+		if p.area >= threshold {
+			break
+		}
+		keep[p.index] = false
+		prev, next := &nodes[p.prev], &nodes[p.next]
+		prev.next, next.prev = p.next, p.prev
+		// An area never drops below the one of the point just removed, so
+		// the removal order follows the visual importance.
+		for _, n := range []*visvalingamPoint{prev, next} {
+			if n.heapIndex < 0 {
+				continue
+			}
+			n.area = math.Max(area(n), p.area)
+			heap.Fix(&h, n.heapIndex)
+		}
+	}
+	return keptLocations(locs, keep)
+}
+
+// SimplifiedStepPaths returns the paths of the steps of the route, in the
+// order of Steps, each simplified by simplify.
+//
+// Paths are simplified one by one and keep their endpoints, so the steps
+// still join and their instructions still apply where they start.
+func (r *DirectionsRoute) SimplifiedStepPaths(simplify SimplifyFunc, tolerance float64) ([][]Location, error) {
+	steps, err := r.Steps()
+	if err != nil {
+		return nil, err
+	}
+	paths := make([][]Location, 0, len(steps))
+	for _, step := range steps {
+		path, err := step.Path()
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, simplify(path, tolerance))
+	}
+	return paths, nil
+}
@@ -0,0 +1,102 @@
+package am_test
+
+import (
+	"testing"
+
+	am "github.com/ringsaturn/am"
+	"github.com/stretchr/testify/assert"
+)
+
+var simplifyFuncs = map[string]am.SimplifyFunc{
+	"DouglasPeucker": am.SimplifyDouglasPeucker,
+	"Visvalingam":    am.SimplifyVisvalingam,
+}
+
+func TestSimplify(t *testing.T) {
+	// About 11 m between points along the equator, with a 55 m spike.
+	straight := []am.Location{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 0, Longitude: 0.0001},
+		{Latitude: 0, Longitude: 0.0002},
+		{Latitude: 0, Longitude: 0.0003},
+		{Latitude: 0, Longitude: 0.0004},
+	}
+	spike := append([]am.Location(nil), straight...)
+	spike[2] = am.Location{Latitude: 0.0005, Longitude: 0.0002}
+
+	for name, simplify := range simplifyFuncs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, []am.Location{straight[0], straight[4]}, simplify(straight, 1))
+			assert.Equal(t, []am.Location{spike[0], spike[2], spike[4]}, simplify(spike, 20))
+			assert.Equal(t, []am.Location{spike[0], spike[4]}, simplify(spike, 1000))
+			assert.Equal(t, spike, simplify(spike, 0))
+			assert.Equal(t, spike[:2], simplify(spike[:2], 1000))
+			assert.Empty(t, simplify(nil, 10))
+		})
+	}
+}
+
+func TestSimplify_Antimeridian(t *testing.T) {
+	locs := []am.Location{
+		{Latitude: 10, Longitude: 179.9998},
+		{Latitude: 10, Longitude: 179.9999},
+		{Latitude: 10, Longitude: -180},
+		{Latitude: 10, Longitude: -179.9999},
+		{Latitude: 10, Longitude: -179.9998},
+	}
+	for name, simplify := range simplifyFuncs {
+		assert.Equal(t, []am.Location{locs[0], locs[4]}, simplify(locs, 1), name)
+	}
+}
+
+func TestDirectionsRoute_SimplifiedStepPaths(t *testing.T) {
+	resp := loadDirectionsResponse(t)
+	route := resp.Route(0)
+	steps, _ := route.Steps()
+
+	for name, simplify := range simplifyFuncs {
+		paths, err := route.SimplifiedStepPaths(simplify, 20)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, paths, len(steps), name)
+		for i, step := range steps {
+			path, _ := step.Path()
+			assert.LessOrEqual(t, len(paths[i]), len(path), name)
+			if len(path) > 0 {
+				assert.Equal(t, path[0], paths[i][0], name)
+				assert.Equal(t, path[len(path)-1], paths[i][len(paths[i])-1], name)
+			}
+		}
+	}
+}
+
+func benchmarkSimplify(b *testing.B, simplify am.SimplifyFunc) {
+	resp := loadDirectionsResponse(b)
+	var polylines [][]am.Location
+	points := 0
+	for i := range resp.Routes {
+		polyline, err := resp.Route(i).Polyline()
+		if err != nil {
+			b.Fatal(err)
+		}
+		polylines = append(polylines, polyline)
+		points += len(polyline)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, polyline := range polylines {
+			simplify(polyline, 10)
+		}
+	}
+	b.ReportMetric(float64(points)*float64(b.N)/b.Elapsed().Seconds(), "points/s")
+}
+
+func BenchmarkSimplifyDouglasPeucker(b *testing.B) {
+	benchmarkSimplify(b, am.SimplifyDouglasPeucker)
+}
+
+func BenchmarkSimplifyVisvalingam(b *testing.B) {
+	benchmarkSimplify(b, am.SimplifyVisvalingam)
+}